@@ -1,65 +1,111 @@
 package client
 
 import (
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/json"
-	"encoding/pem"
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"net/http"
-	"net/url"
+	"io/fs"
+	"strings"
 	"time"
 
-	"github.com/lestrrat-go/jwx/jwa"
 	"github.com/lestrrat-go/jwx/jwt"
 )
 
 type accessToken string
 
-func (a accessToken) IsValid() (jwt.Token, bool) {
-	block, _ := pem.Decode([]byte(jwtPublicKey))
-	cert, err := x509.ParseCertificate(block.Bytes)
-	if err != nil {
-		return nil, false
-	}
-	rsaPublicKey := cert.PublicKey.(*rsa.PublicKey)
+// TokenInfo describes the access token cached for the configured credentials
+type TokenInfo struct {
+	// Name of the token in the token store
+	Key       string    `json:"key"`
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	Audience  []string  `json:"audience"`
+	Scopes    []string  `json:"scopes"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Claims other than the registered JWT ones, such as those naming the
+	// organizations the subject belongs to
+	Claims map[string]interface{} `json:"claims,omitempty"`
+	// Client the refresh token cached along with the access token was issued
+	// to, if there is one
+	ClientID        string `json:"clientId,omitempty"`
+	HasRefreshToken bool   `json:"hasRefreshToken"`
+	// Why the token would not be used, e.g. because it has expired or was
+	// issued by another identity provider. Empty when the token is valid.
+	Problem string `json:"problem,omitempty"`
+}
 
-	token, err := jwt.ParseString(string(a),
-		jwt.WithVerify(jwa.RS256, rsaPublicKey))
-	// Invalid signature
+// TokenInspect describes the access token cached for the configured
+// credentials, without obtaining a new one. The error wraps fs.ErrNotExist
+// when no token is cached.
+func (c *Client) TokenInspect(ctx context.Context) (*TokenInfo, error) {
+	key := c.tokenKey()
+	cached, err := c.tokenStore.get(key)
 	if err != nil {
-		return token, false
+		return nil, fmt.Errorf("error getting token from store: %w", err)
 	}
 
-	err = jwt.Validate(token, jwt.WithAcceptableSkew(30*time.Second))
-	// Token has expired
+	// The claims are read without checking the signature, so that tokens
+	// which fail validation can still be told apart
+	parsed, err := jwt.ParseString(string(cached.AccessToken))
 	if err != nil {
-		return token, false
+		return nil, fmt.Errorf("error parsing cached token %q: %w", key, err)
 	}
 
-	for _, tokenAud := range token.Audience() {
-		for _, aud := range []string{"https://api.eventstore.cloud", "qB1dK9gAx6U1H1miH4LfwCp4Q1y3qSeZ"} {
-			if aud == tokenAud {
-				return token, true
-			}
-		}
+	info := &TokenInfo{
+		Key:             key,
+		Subject:         parsed.Subject(),
+		Issuer:          parsed.Issuer(),
+		Audience:        parsed.Audience(),
+		Scopes:          strings.Fields(cached.Scope),
+		IssuedAt:        parsed.IssuedAt(),
+		ExpiresAt:       parsed.Expiration(),
+		Claims:          parsed.PrivateClaims(),
+		ClientID:        cached.ClientID,
+		HasRefreshToken: cached.RefreshToken != "",
+	}
+	if scope, ok := info.Claims["scope"].(string); ok && len(info.Scopes) == 0 {
+		info.Scopes = strings.Fields(scope)
+	}
+	if _, err := c.validateToken(ctx, cached.AccessToken); err != nil {
+		info.Problem = err.Error()
 	}
 
-	return token, true
+	return info, nil
 }
 
-// Inspect a token from the local token store
-func (c *Client) TokenInspect(audience string) (*tokenData, error) {
-	return c.tokenStore.get(audience)
+// TokenRefresh obtains an access token for the configured credentials and
+// caches it, unless a valid one is cached already and force isn't set
+func (c *Client) TokenRefresh(ctx context.Context, force bool) error {
+	_, err := c.accessToken(ctx, force)
+
+	return err
 }
 
-// Convenience function
-func (c *Client) TokenRefresh(force bool) error {
-	_, err := c.accessToken(force)
+// TokenClear removes the access token cached for the configured credentials,
+// along with the refresh token cached with it, such as the one stored by the
+// login command. The error wraps fs.ErrNotExist when no token is cached.
+func (c *Client) TokenClear() error {
+	key := c.tokenKey()
 
-	return err
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	unlock, err := c.tokenStore.lock(key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !c.tokenStore.exists(key) {
+		return fmt.Errorf("no token is cached as %q: %w", key, fs.ErrNotExist)
+	}
+	if err := c.tokenStore.delete(key); err != nil {
+		return fmt.Errorf("error removing token from store: %w", err)
+	}
+
+	return nil
 }
 
 func closeIgnoreError(closer io.Closer) func() {
@@ -68,51 +114,82 @@ func closeIgnoreError(closer io.Closer) func() {
 	}
 }
 
-func (c *Client) accessToken(force bool) (*tokenData, error) {
-	log.Println("[INFO] In the accessToken")
+func (c *Client) accessToken(ctx context.Context, force bool) (*tokenData, error) {
+	key := c.tokenKey()
+	subjectToken, err := c.subjectToken()
+	if err != nil {
+		return nil, err
+	}
+	subjectHash := subjectTokenHash(subjectToken)
+
+	// A valid cached token is used without taking any lock. Validating it
+	// may fetch the identity provider's keys, which is done here so that the
+	// locks below are only held while the store is read and written. Writes
+	// replace tokens atomically, so they are never seen half written.
+	var seen accessToken
+	if !force && c.tokenStore.exists(key) {
+		cached, err := c.tokenStore.get(key)
+		if err == nil {
+			seen = cached.AccessToken
+			err = checkSubjectToken(cached, subjectHash)
+		}
+		if err == nil {
+			_, err = c.validateToken(ctx, cached.AccessToken)
+		}
+		if err == nil {
+			logTrace(ctx, "Using cached access token", map[string]interface{}{"token_key": key})
+			return cached, nil
+		}
+		logDebug(ctx, "Cached access token cannot be used", map[string]interface{}{"reason": err.Error()})
+	}
+
+	// Only one refresh at a time, whether from concurrent operations in this
+	// process or other Terraform processes sharing the store. Whoever waits
+	// finds the token refreshed by the previous holder, which is told apart
+	// from the one found invalid above by having changed meanwhile.
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	unlock, err := c.tokenStore.lock(key)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
-	if c.tokenStore.exists(c.audience) && !force {
-		tokenData, err := c.tokenStore.get(c.audience)
+	var cached *tokenData
+	if c.tokenStore.exists(key) {
+		cached, err = c.tokenStore.get(key)
 		if err != nil {
 			return nil, fmt.Errorf("error getting token from store: %w", err)
 		}
 
-		if _, ok := tokenData.AccessToken.IsValid(); ok {
-			return tokenData, nil
+		if !force && cached.AccessToken != seen && checkSubjectToken(cached, subjectHash) == nil {
+			logTrace(ctx, "Using access token refreshed by another operation", map[string]interface{}{"token_key": key})
+			return cached, nil
 		}
 	}
 
-	log.Println("[INFO] In the IDP")
+	logDebug(ctx, "Requesting access token from the identity provider", map[string]interface{}{"token_key": key})
 
-	// Do the refresh
-	idpURL := *c.idpURL
-	idpURL.Path = "/oauth/token"
-
-	form := url.Values{}
-	form.Set("grant_type", "refresh_token")
-	form.Set("client_id", c.clientID)
-	form.Set("refresh_token", c.refreshToken)
-
-	resp, err := c.httpClient.PostForm(idpURL.String(), form)
+	result, err := c.requestToken(ctx, cached)
 	if err != nil {
-		return nil, fmt.Errorf("error requesting access token: %w", err)
+		return nil, err
 	}
-	defer closeIgnoreError(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("error %d requesting access token", resp.StatusCode)
+	err = c.tokenStore.put(key, *result)
+	if err != nil {
+		return nil, fmt.Errorf("error writing token to store: %s", err.Error())
 	}
 
-	decoder := json.NewDecoder(resp.Body)
-	result := tokenData{}
-	if err := decoder.Decode(&result); err != nil {
-		return nil, fmt.Errorf("error parsing IDP response: %w", err)
-	}
+	return result, err
+}
 
-	err = c.tokenStore.put(c.audience, result)
-	if err != nil {
-		return nil, fmt.Errorf("error writing token to store: %s", err.Error())
+// checkSubjectToken rejects a cached token exchanged for another subject token
+// than subjectHash identifies, e.g. the OIDC token of an earlier CI job
+func checkSubjectToken(cached *tokenData, subjectHash string) error {
+	if cached.SubjectTokenHash != subjectHash {
+		return errors.New("the token was exchanged for another subject token")
 	}
 
-	return &result, err
+	return nil
 }
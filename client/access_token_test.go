@@ -0,0 +1,273 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// newFileStoreClient returns a client for server keeping its tokens in a
+// temporary directory, so that they outlive the client
+func newFileStoreClient(t *testing.T, server *clienttest.Server, tokenStore string) *client.Client {
+	t.Helper()
+
+	config := server.ClientConfig()
+	config.TokenStoreType = client.TokenStoreFile
+	config.TokenStore = tokenStore
+
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+	return c
+}
+
+func tokenRequests(server *clienttest.Server) int {
+	count := 0
+	for _, request := range server.Requests() {
+		if request.Path == "/oauth/token" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestTokenLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(&clienttest.Config{
+		TokenClaims: map[string]interface{}{"https://eventstore.cloud/org_id": clienttest.DefaultOrganizationID},
+	})
+	t.Cleanup(server.Close)
+	tokenStore := t.TempDir()
+	c := newFileStoreClient(t, server, tokenStore)
+
+	if _, err := c.TokenInspect(ctx); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected no cached token, got %v", err)
+	}
+
+	if err := c.TokenRefresh(ctx, false); err != nil {
+		t.Fatalf("refreshing token: %s", err)
+	}
+
+	// Another client sharing the store sees the same token
+	info, err := newFileStoreClient(t, server, tokenStore).TokenInspect(ctx)
+	if err != nil {
+		t.Fatalf("inspecting token: %s", err)
+	}
+	if info.Subject != "user|clienttest" || info.Problem != "" {
+		t.Errorf("expected a valid token for the user, got %+v", info)
+	}
+	if len(info.Audience) != 1 || info.Audience[0] != "https://api.eventstore.cloud" {
+		t.Errorf("unexpected audience %v", info.Audience)
+	}
+	if len(info.Scopes) != 2 || info.Scopes[1] != "offline_access" {
+		t.Errorf("unexpected scopes %v", info.Scopes)
+	}
+	if lifetime := info.ExpiresAt.Sub(info.IssuedAt); lifetime != time.Hour {
+		t.Errorf("expected the token to last an hour, got %s", lifetime)
+	}
+	if info.Claims["https://eventstore.cloud/org_id"] != clienttest.DefaultOrganizationID {
+		t.Errorf("expected the organization claim, got %v", info.Claims)
+	}
+
+	if err := c.TokenRefresh(ctx, false); err != nil {
+		t.Fatalf("refreshing token: %s", err)
+	}
+	if issued := tokenRequests(server); issued != 1 {
+		t.Errorf("expected the cached token to be used, got %d token requests", issued)
+	}
+	if err := c.TokenRefresh(ctx, true); err != nil {
+		t.Fatalf("forcing refresh: %s", err)
+	}
+	if issued := tokenRequests(server); issued != 2 {
+		t.Errorf("expected a new token to be requested, got %d token requests", issued)
+	}
+
+	if err := c.TokenClear(); err != nil {
+		t.Fatalf("clearing token: %s", err)
+	}
+	if _, err := c.TokenInspect(ctx); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the token to be gone, got %v", err)
+	}
+	if err := c.TokenClear(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected clearing again to report no token, got %v", err)
+	}
+}
+
+func TestTokenInspectReportsInvalidTokens(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(nil)
+	t.Cleanup(server.Close)
+	tokenStore := t.TempDir()
+
+	if err := newFileStoreClient(t, server, tokenStore).TokenRefresh(ctx, false); err != nil {
+		t.Fatalf("refreshing token: %s", err)
+	}
+
+	// Without the identity provider's keys the token can't be validated, but
+	// it is still described
+	c := newFileStoreClient(t, server, tokenStore)
+	server.Close()
+
+	info, err := c.TokenInspect(ctx)
+	if err != nil {
+		t.Fatalf("inspecting token: %s", err)
+	}
+	if info.Subject != "user|clienttest" || info.Problem == "" {
+		t.Errorf("expected the token to be described along with a problem, got %+v", info)
+	}
+}
+
+func TestConcurrentRefreshesRequestOneToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// Clients refreshing concurrently, which share their token store
+		clients func(t *testing.T, server *clienttest.Server) []*client.Client
+	}{
+		{
+			name: "memory store",
+			clients: func(t *testing.T, server *clienttest.Server) []*client.Client {
+				c, err := server.NewClient()
+				if err != nil {
+					t.Fatal(err)
+				}
+				return []*client.Client{c}
+			},
+		},
+		{
+			name: "file store",
+			clients: func(t *testing.T, server *clienttest.Server) []*client.Client {
+				tokenStore := t.TempDir()
+				return []*client.Client{
+					newFileStoreClient(t, server, tokenStore),
+					newFileStoreClient(t, server, tokenStore),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := clienttest.NewServer(nil)
+			t.Cleanup(server.Close)
+			clients := tt.clients(t, server)
+
+			errs := make(chan error)
+			for i := 0; i < 10; i++ {
+				c := clients[i%len(clients)]
+				go func() {
+					errs <- c.TokenRefresh(context.Background(), false)
+				}()
+			}
+			for i := 0; i < 10; i++ {
+				if err := <-errs; err != nil {
+					t.Errorf("refreshing token: %s", err)
+				}
+			}
+
+			if issued := tokenRequests(server); issued != 1 {
+				t.Errorf("expected a single token request, got %d", issued)
+			}
+		})
+	}
+}
+
+func TestEncryptedTokenStoreSharesDirectoryWithPlaintextTokens(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(nil)
+	t.Cleanup(server.Close)
+	tokenStore := t.TempDir()
+
+	plaintext := newFileStoreClient(t, server, tokenStore)
+	if err := plaintext.TokenRefresh(ctx, false); err != nil {
+		t.Fatalf("refreshing plaintext token: %s", err)
+	}
+
+	config := server.ClientConfig()
+	config.TokenStoreType = client.TokenStoreEncrypted
+	config.TokenStore = tokenStore
+	config.TokenStoreKey = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	encrypted, err := client.New(config)
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	// The plaintext token is not mistaken for an encrypted one
+	if _, err := encrypted.OrganizationList(ctx); err != nil {
+		t.Fatalf("calling the API with an encrypted token store: %s", err)
+	}
+	if issued := tokenRequests(server); issued != 2 {
+		t.Errorf("expected a token to be requested for each store, got %d token requests", issued)
+	}
+
+	if info, err := plaintext.TokenInspect(ctx); err != nil || info.Problem != "" {
+		t.Errorf("expected the plaintext token to be left valid, got %+v (%v)", info, err)
+	}
+}
+
+func TestTokenLocksAreNotHeldWhileFetchingKeys(t *testing.T) {
+	server := clienttest.NewServer(nil)
+	t.Cleanup(server.Close)
+	tokenStore := t.TempDir()
+
+	if err := newFileStoreClient(t, server, tokenStore).TokenRefresh(context.Background(), false); err != nil {
+		t.Fatalf("refreshing token: %s", err)
+	}
+	info, err := newFileStoreClient(t, server, tokenStore).TokenInspect(context.Background())
+	if err != nil {
+		t.Fatalf("inspecting token: %s", err)
+	}
+
+	// A new client has to fetch the keys to validate the cached token, from
+	// an identity provider which is slow to answer
+	server.InjectFault(clienttest.Fault{PathPrefix: "/.well-known/jwks.json", Delay: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- newFileStoreClient(t, server, tokenStore).TokenRefresh(ctx, false)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for keyRequests(server) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the keys to be fetched")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Another process sharing the store can still take the lock
+	locked := make(chan func())
+	go func() {
+		unlock, _ := client.NewFileTokenStore(tokenStore).Lock(info.Key)
+		locked <- unlock
+	}()
+	select {
+	case unlock := <-locked:
+		unlock()
+	case <-time.After(5 * time.Second):
+		cancel()
+		(<-locked)()
+		t.Fatal("expected the token lock to be free while the keys are fetched")
+	}
+}
+
+func keyRequests(server *clienttest.Server) int {
+	count := 0
+	for _, request := range server.Requests() {
+		if request.Path == "/.well-known/jwks.json" {
+			count++
+		}
+	}
+	return count
+}
@@ -2,10 +2,6 @@ package client
 
 import (
 	"context"
-	"net/http"
-	"path"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type DeleteAclRequest struct {
@@ -14,27 +10,6 @@ type DeleteAclRequest struct {
 	AclID          string
 }
 
-func (c *Client) AclDelete(ctx context.Context, req *DeleteAclRequest) diag.Diagnostics {
-	requestURL := *c.apiURL
-	requestURL.Path = path.Join("infra", "v1", "organizations", req.OrganizationID, "projects", req.ProjectID, "acls", req.AclID)
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, requestURL.String(), nil)
-	if err != nil {
-		return diag.Errorf("error constructing request: %w", err)
-	}
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return diag.Errorf("error sending request: %w", err)
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return translateStatusCode(resp.StatusCode, "deleting acl", resp.Body)
-	}
-
-	return nil
+func (c *Client) AclDelete(ctx context.Context, req *DeleteAclRequest) error {
+	return c.api.DeleteAcl(ctx, req.OrganizationID, req.ProjectID, req.AclID)
 }
@@ -0,0 +1,511 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// The tests in this file replay API responses from the cassettes in
+// testdata/cassettes, and check that the client decodes them. The cassettes
+// were written by hand from the API's documentation, so until they are
+// recorded they only check the client against that documentation, and can't
+// catch the API drifting from it. To record them from the live API, run
+//
+//	ESC_RECORD=1 ESC_TOKEN=... ESC_ORG_ID=... ESC_RECORD_CLUSTER_ID=... go test ./client -run TestCassette
+//
+// which reads the resources named by the ESC_RECORD_*_ID variables, skipping
+// calls for which none is set. Responses must then decode into the client's
+// types without losing any of the fields they declare.
+
+// Resources read by the recorded calls
+type recordedIDs struct {
+	organization string
+	project      string
+	network      string
+	peering      string
+	acl          string
+	cluster      string
+	job          string
+	integration  string
+}
+
+// The resources read in the cassettes
+var replayedIDs = recordedIDs{
+	organization: "cbmt4lhpl6b01k2rqmeg",
+	project:      "cbmt4mtpl6b01k2rqmf0",
+	network:      "cbmt4o5pl6b01k2rqmfg",
+	peering:      "cbmt4pdpl6b01k2rqmg0",
+	acl:          "cbmt4qlpl6b01k2rqmgg",
+	cluster:      "cbmt4rtpl6b01k2rqmh0",
+	job:          "cbmt4t5pl6b01k2rqmhg",
+	integration:  "cbmt4udpl6b01k2rqmi0",
+}
+
+const replayedAPIURL = "https://api.eventstore.cloud"
+
+func TestCassetteDecoding(t *testing.T) {
+	tests := []struct {
+		cassette string
+		// IDs the call needs, named as in the ESC_RECORD_*_ID variables
+		needs    []string
+		call     func(context.Context, *client.Client, recordedIDs) (interface{}, error)
+		expected interface{}
+	}{
+		{
+			cassette: "list_organizations",
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.OrganizationList(ctx)
+			},
+			expected: &client.ListOrganizationsResponse{
+				Organizations: []client.Organization{{
+					OrganizationID: replayedIDs.organization,
+					Name:           "Example Org",
+					Created:        "2022-08-10T09:12:54Z",
+				}},
+			},
+		},
+		{
+			cassette: "get_project",
+			needs:    []string{"PROJECT"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.ProjectGet(ctx, &client.GetProjectRequest{
+					OrganizationID: ids.organization,
+					ProjectID:      ids.project,
+				})
+			},
+			expected: &client.GetProjectResponse{
+				Project: client.Project{
+					ProjectID:      replayedIDs.project,
+					OrganizationID: replayedIDs.organization,
+					Name:           "Example Project",
+					Created:        "2022-08-10T09:14:11Z",
+				},
+			},
+		},
+		{
+			cassette: "get_network",
+			needs:    []string{"PROJECT", "NETWORK"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.NetworkGet(ctx, &client.GetNetworkRequest{
+					OrganizationID: ids.organization,
+					ProjectID:      ids.project,
+					NetworkID:      ids.network,
+				})
+			},
+			expected: &client.GetNetworkResponse{
+				Network: client.Network{
+					NetworkID: replayedIDs.network,
+					ProjectID: replayedIDs.project,
+					Provider:  "aws",
+					Region:    "us-west-2",
+					CIDRBlock: "172.21.0.0/16",
+					Name:      "Example Network",
+					Status:    "available",
+				},
+			},
+		},
+		{
+			cassette: "get_peering",
+			needs:    []string{"PROJECT", "PEERING"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.PeeringGet(ctx, &client.GetPeeringRequest{
+					OrganizationID: ids.organization,
+					ProjectID:      ids.project,
+					PeeringID:      ids.peering,
+				})
+			},
+			expected: &client.GetPeeringResponse{
+				Peering: client.Peering{
+					ProjectID:               replayedIDs.project,
+					PeeringID:               replayedIDs.peering,
+					NetworkID:               replayedIDs.network,
+					Provider:                "aws",
+					Name:                    "Example Peering",
+					PeerAccountIdentifier:   "123456789012",
+					PeerNetworkIdentifier:   "vpc-0123456789abcdef0",
+					PeerNetworkRegion:       "us-west-2",
+					ProviderPeeringMetadata: map[string]string{"peeringLinkId": "pcx-0a1b2c3d4e5f67890"},
+					Routes:                  []string{"10.0.0.0/16"},
+					Status:                  "initiated",
+					Created:                 "2022-08-10T09:31:02Z",
+				},
+			},
+		},
+		{
+			cassette: "get_acl",
+			needs:    []string{"PROJECT", "ACL"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.AclGet(ctx, &client.GetAclRequest{
+					OrganizationID: ids.organization,
+					ProjectID:      ids.project,
+					AclID:          ids.acl,
+				})
+			},
+			expected: &client.GetAclResponse{
+				Acl: client.Acl{
+					OrganizationID: replayedIDs.organization,
+					ProjectID:      replayedIDs.project,
+					CidrBlocks:     []client.AclCidrBlock{{Address: "192.0.2.0/24", Comment: "office"}},
+					Created:        "2022-08-10T09:40:12Z",
+					Name:           "Example ACL",
+					Status:         "available",
+					Updated:        "2022-08-10T09:40:12Z",
+				},
+			},
+		},
+		{
+			cassette: "get_managed_cluster",
+			needs:    []string{"PROJECT", "CLUSTER"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.ManagedClusterGet(ctx, &client.GetManagedClusterRequest{
+					OrganizationID: ids.organization,
+					ProjectID:      ids.project,
+					ClusterID:      ids.cluster,
+				})
+			},
+			expected: &client.GetManagedClusterResponse{
+				ManagedCluster: client.ManagedCluster{
+					OrganizationID:   replayedIDs.organization,
+					ProjectID:        replayedIDs.project,
+					NetworkID:        replayedIDs.network,
+					ClusterID:        replayedIDs.cluster,
+					Name:             "Example Cluster",
+					Provider:         "aws",
+					Region:           "us-west-2",
+					Topology:         "three-node-multi-zone",
+					InstanceType:     "c4",
+					DiskSizeGB:       16,
+					DiskType:         "gp3",
+					DiskIops:         3000,
+					DiskThroughput:   125,
+					ServerVersion:    "24.10",
+					ServerVersionTag: "24.10.1",
+					ProjectionLevel:  "user",
+					Status:           "available",
+					Created:          "2022-08-10T09:45:27Z",
+					Protected:        true,
+				},
+			},
+		},
+		{
+			cassette: "get_initial_credentials",
+			needs:    []string{"PROJECT", "CLUSTER"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.ManagedClusterGetInitialCredentials(ctx, &client.GetManagedClusterInitialCredentialsRequest{
+					OrganizationID: ids.organization,
+					ProjectID:      ids.project,
+					ClusterID:      ids.cluster,
+				})
+			},
+			expected: &client.GetManagedClusterInitialCredentialsResponse{
+				AdminPassword: "[SCRUBBED]",
+				OpsPassword:   "[SCRUBBED]",
+				GeneratedAt:   "2022-08-10T09:52:40Z",
+				ClusterID:     replayedIDs.cluster,
+			},
+		},
+		{
+			cassette: "get_job",
+			needs:    []string{"PROJECT", "JOB"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.GetJob(ctx, ids.organization, ids.project, ids.job)
+			},
+			expected: &client.GetJobResponse{
+				Job: client.Job{
+					Data: map[string]interface{}{
+						"clusterId":      replayedIDs.cluster,
+						"description":    "{cluster} at {datetime}",
+						"maxBackupCount": float64(7),
+					},
+					Description:    "Nightly backups",
+					Id:             replayedIDs.job,
+					OrganizationId: replayedIDs.organization,
+					ProjectId:      replayedIDs.project,
+					Schedule:       "0 2 * * *",
+					Status:         "active",
+					Type:           "ScheduledBackup",
+				},
+			},
+		},
+		{
+			cassette: "get_integration",
+			needs:    []string{"PROJECT", "INTEGRATION"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.GetIntegration(ctx, ids.organization, ids.project, ids.integration)
+			},
+			expected: &client.GetIntegrationResponse{
+				Integration: client.Integration{
+					Created: time.Date(2022, 8, 10, 10, 2, 18, 123456000, time.UTC),
+					Data: map[string]interface{}{
+						"channelId":    "#esc-alerts",
+						"sink":         "slack",
+						"source":       "issues",
+						"tokenDisplay": "****wxyz",
+					},
+					Description:    "Slack alerts",
+					Id:             replayedIDs.integration,
+					OrganizationId: replayedIDs.organization,
+					ProjectId:      replayedIDs.project,
+					Status:         client.ACTIVE,
+					Updated:        time.Date(2022, 8, 11, 8, 15, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			cassette: "list_integrations",
+			needs:    []string{"PROJECT"},
+			call: func(ctx context.Context, c *client.Client, ids recordedIDs) (interface{}, error) {
+				return c.ListIntegrations(ctx, ids.organization, ids.project)
+			},
+			expected: &client.ListIntegrationsResponse{
+				Integrations: []client.Integration{{
+					Created: time.Date(2022, 8, 10, 10, 2, 18, 123456000, time.UTC),
+					Data: map[string]interface{}{
+						"channelId":    "#esc-alerts",
+						"sink":         "slack",
+						"source":       "issues",
+						"tokenDisplay": "****wxyz",
+					},
+					Description:    "Slack alerts",
+					Id:             replayedIDs.integration,
+					OrganizationId: replayedIDs.organization,
+					ProjectId:      replayedIDs.project,
+					Status:         client.ACTIVE,
+					Updated:        time.Date(2022, 8, 11, 8, 15, 0, 0, time.UTC),
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cassette, func(t *testing.T) {
+			c, ids, lastBody := newRecordedClient(t, tt.cassette, tt.needs...)
+
+			got, err := tt.call(context.Background(), c, ids)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkDecodedFields(t, lastBody(), got)
+			if recording() {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected\n%#v\ngot\n%#v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCassetteNotFound(t *testing.T) {
+	if recording() {
+		t.Skip("there is no way to record reading a missing cluster on purpose")
+	}
+
+	c, ids, _ := newRecordedClient(t, "get_managed_cluster_not_found")
+
+	_, err := c.ManagedClusterGet(context.Background(), &client.GetManagedClusterRequest{
+		OrganizationID: ids.organization,
+		ProjectID:      ids.project,
+		ClusterID:      ids.cluster,
+	})
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T", err)
+	}
+	if expected := "error getting managed cluster (status 404): Not Found: cluster " + ids.cluster + " not found"; apiErr.Message() != expected {
+		t.Errorf("expected message %q, got %q", expected, apiErr.Message())
+	}
+}
+
+func recording() bool {
+	return os.Getenv("ESC_RECORD") != ""
+}
+
+// newRecordedClient returns a client replaying the named cassette, or
+// recording it from the live API when ESC_RECORD is set, along with the IDs
+// of the resources to read and a function returning the last response body
+// from the API. The IDs named by needs must be set when recording.
+func newRecordedClient(t *testing.T, cassette string, needs ...string) (*client.Client, recordedIDs, func() []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "cassettes", cassette+".json")
+
+	var config *client.Config
+	var ids recordedIDs
+	mode := clienttest.ModeReplay
+	if recording() {
+		for _, name := range needs {
+			if os.Getenv("ESC_RECORD_"+name+"_ID") == "" {
+				t.Skipf("ESC_RECORD_%s_ID is not set", name)
+			}
+		}
+
+		mode = clienttest.ModeRecord
+		config = &client.Config{
+			URL:                 firstNonEmpty(os.Getenv("ESC_URL"), replayedAPIURL),
+			IdentityProviderURL: os.Getenv("ESC_IDENTITY_PROVIDER_URL"),
+			ClientID:            os.Getenv("ESC_CLIENT_ID"),
+			RefreshToken:        os.Getenv("ESC_TOKEN"),
+			TokenStoreType:      client.TokenStoreMemory,
+		}
+		ids = recordedIDs{
+			organization: os.Getenv("ESC_ORG_ID"),
+			project:      os.Getenv("ESC_RECORD_PROJECT_ID"),
+			network:      os.Getenv("ESC_RECORD_NETWORK_ID"),
+			peering:      os.Getenv("ESC_RECORD_PEERING_ID"),
+			acl:          os.Getenv("ESC_RECORD_ACL_ID"),
+			cluster:      os.Getenv("ESC_RECORD_CLUSTER_ID"),
+			job:          os.Getenv("ESC_RECORD_JOB_ID"),
+			integration:  os.Getenv("ESC_RECORD_INTEGRATION_ID"),
+		}
+	} else {
+		// Only API calls are replayed, so the fake stands in for the
+		// identity provider
+		server := clienttest.NewServer(nil)
+		t.Cleanup(server.Close)
+
+		config = server.ClientConfig()
+		config.URL = replayedAPIURL
+		ids = replayedIDs
+	}
+
+	recorder, err := clienttest.NewRecorder(path, mode, config.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recorder.Recorded() {
+		t.Logf("cassette %s was written by hand, so only the documented response is checked", cassette)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	apiURL, err := url.Parse(config.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lastBody []byte
+	config.WrapTransport = func(next http.RoundTripper) http.RoundTripper {
+		recorded := recorder.Wrap(next)
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := recorded.RoundTrip(req)
+			if err != nil || req.URL.Host != apiURL.Host {
+				return resp, err
+			}
+			lastBody, err = io.ReadAll(resp.Body)
+			resp.Body = io.NopCloser(bytes.NewReader(lastBody))
+			return resp, err
+		})
+	}
+
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, ids, func() []byte { return lastBody }
+}
+
+// checkDecodedFields fails the test when the response lacks fields which
+// the decoded type declares, as happens when the API renames or drops them.
+// Fields of the response the type ignores are only logged.
+func checkDecodedFields(t *testing.T, body []byte, decoded interface{}) {
+	t.Helper()
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, actual interface{}
+	if err := json.Unmarshal(encoded, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &actual); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+
+	missing, unknown := compareFields("", expected, actual)
+	for _, field := range missing {
+		t.Errorf("response lacks field %s", field)
+	}
+	for _, field := range unknown {
+		t.Logf("response has field %s, which is not decoded", field)
+	}
+}
+
+// compareFields returns the paths of the object fields in expected which
+// are missing from actual, and of those in actual which expected lacks.
+// Arrays are compared by their first element.
+func compareFields(path string, expected, actual interface{}) ([]string, []string) {
+	var missing, unknown []string
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		for key, value := range e {
+			actualValue, found := a[key]
+			if !found {
+				missing = append(missing, path+"."+key)
+				continue
+			}
+			m, u := compareFields(path+"."+key, value, actualValue)
+			missing = append(missing, m...)
+			unknown = append(unknown, u...)
+		}
+		for key := range a {
+			if _, found := e[key]; !found {
+				unknown = append(unknown, path+"."+key)
+			}
+		}
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if ok && len(e) > 0 && len(a) > 0 {
+			return compareFields(path+"[0]", e[0], a[0])
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(unknown)
+	return missing, unknown
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -7,10 +7,15 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
 
-	"github.com/hashicorp/go-cleanhttp"
+const (
+	defaultIdentityProviderURL = "https://identity.eventstore.com"
+	defaultUserAgent           = "terraform-provider-eventstorecloud"
 )
 
 type Config struct {
@@ -19,6 +24,56 @@ type Config struct {
 	ClientID            string
 	TokenStore          string
 	RefreshToken        string
+
+	// When set, tokens are obtained with the client credentials grant for
+	// ClientID instead of exchanging RefreshToken
+	ClientSecret string
+
+	// An OIDC token issued to the current workload, e.g. by GitHub Actions,
+	// which is traded for an access token using the RFC 8693 token exchange
+	// grant. Only one of OIDCToken and OIDCTokenFile may be set.
+	OIDCToken string
+	// Path to a file holding the OIDC token. The file is re-read whenever a
+	// new access token is needed, so it may be rotated by the CI system.
+	OIDCTokenFile string
+
+	// One of TokenStoreFile (the default), TokenStoreEncrypted or
+	// TokenStoreMemory. TokenStore is the directory used by the first two.
+	TokenStoreType string
+	// Base64 encoded 32-byte key for the encrypted token store, given
+	// directly or in a file
+	TokenStoreKey     string
+	TokenStoreKeyFile string
+
+	// Sent with every request, e.g.
+	// terraform-provider-eventstorecloud/1.5.30 terraform/1.9.0
+	UserAgent string
+
+	// PEM bundle of CAs trusted in addition to the system ones, e.g. that of
+	// a TLS-intercepting proxy
+	CAFile string
+	// PEM certificate and key presented to servers requiring mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+	// Proxy for all calls. When empty, HTTPS_PROXY and friends are used.
+	ProxyURL string
+	// Disables verification of server certificates. Only ever meant for
+	// debugging, as it allows tokens to be intercepted.
+	InsecureSkipVerify bool
+	// Timeouts for establishing connections and for each attempt at an HTTP
+	// call. Zero means 30 seconds and no timeout respectively.
+	ConnectTimeout time.Duration
+	RequestTimeout time.Duration
+
+	// Wraps the transport used for every call when set, e.g. to record and
+	// replay API calls in tests
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+
+	// Maximum number of attempts for a single API call, including the first
+	RetryMaxAttempts int
+	// Bounds for the exponential backoff between attempts
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
 }
 
 func (config *Config) validate() error {
@@ -26,6 +81,18 @@ func (config *Config) validate() error {
 		return errors.New("URL is required")
 	}
 
+	if config.OIDCToken != "" && config.OIDCTokenFile != "" {
+		return errors.New("only one of OIDC token and OIDC token file may be set")
+	}
+
+	switch config.TokenStoreType {
+	case "", TokenStoreFile, TokenStoreEncrypted:
+	case TokenStoreMemory:
+		return nil
+	default:
+		return fmt.Errorf("unknown token store type %q", config.TokenStoreType)
+	}
+
 	if _, err := os.Stat(config.TokenStore); err != nil {
 		if os.IsNotExist(err) {
 			err := os.MkdirAll(config.TokenStore, 0o700)
@@ -44,14 +111,46 @@ func (config *Config) validate() error {
 
 type Client struct {
 	apiURL *url.URL
+	// Generated from the API's OpenAPI description, and wrapped by the
+	// hand-written methods
+	api *escapi.Client
 
 	audience     string
 	idpURL       *url.URL
 	tokenStore   *tokenStore
 	clientID     string
+	clientSecret string
 	refreshToken string
 
+	oidcToken     string
+	oidcTokenFile string
+
+	// Serializes access token refreshes
+	tokenMutex sync.Mutex
+	// Signing keys of the identity provider
+	jwks *jwksCache
+
 	httpClient *http.Client
+	userAgent  string
+
+	retryMaxAttempts int
+	retryWaitMin     time.Duration
+	retryWaitMax     time.Duration
+}
+
+func newTokenStore(opts *Config) (TokenStore, error) {
+	switch opts.TokenStoreType {
+	case TokenStoreMemory:
+		return NewMemoryTokenStore(), nil
+	case TokenStoreEncrypted:
+		key, err := readTokenStoreKey(opts.TokenStoreKey, opts.TokenStoreKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewEncryptedTokenStore(NewFileTokenStore(opts.TokenStore), key)
+	}
+
+	return NewFileTokenStore(opts.TokenStore), nil
 }
 
 func New(opts *Config) (*Client, error) {
@@ -59,8 +158,12 @@ func New(opts *Config) (*Client, error) {
 		return nil, err
 	}
 
+	backend, err := newTokenStore(opts)
+	if err != nil {
+		return nil, err
+	}
 	tokenStore := &tokenStore{
-		path: opts.TokenStore,
+		backend: backend,
 	}
 
 	apiURL, err := url.Parse(opts.URL)
@@ -70,33 +173,84 @@ func New(opts *Config) (*Client, error) {
 
 	identityProviderURL := opts.IdentityProviderURL
 	if strings.TrimSpace(identityProviderURL) == "" {
-		identityProviderURL = "https://identity.eventstore.com"
+		identityProviderURL = defaultIdentityProviderURL
 	}
 	parsedIdentityProviderURL, err := url.Parse(identityProviderURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid identity provider URL: %q, %w", identityProviderURL, err)
 	}
 
+	// Tokens are cached per audience. Those issued by another identity
+	// provider, e.g. for staging, must not be mixed up with production ones
+	// in a shared token store.
+	audience := "api.eventstore.cloud"
+	if identityProviderURL != defaultIdentityProviderURL {
+		audience += "@" + strings.ReplaceAll(parsedIdentityProviderURL.Host, ":", "_")
+	}
+
 	clientID := opts.ClientID
 	if strings.TrimSpace(clientID) == "" {
+		if opts.ClientSecret != "" {
+			return nil, errors.New("a client ID is required when using a client secret")
+		}
 		clientID = "OraYp3cFES9O8aWuQtnqi1A7m534iTwt"
 	}
 
-	return &Client{
-		apiURL:       apiURL,
-		audience:     "api.eventstore.cloud",
-		idpURL:       parsedIdentityProviderURL,
-		clientID:     clientID,
-		tokenStore:   tokenStore,
-		refreshToken: opts.RefreshToken,
-		httpClient:   cleanhttp.DefaultClient(),
-	}, nil
+	httpClient, err := newHTTPClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+
+	retryMaxAttempts := opts.RetryMaxAttempts
+	if retryMaxAttempts <= 0 {
+		retryMaxAttempts = defaultRetryMaxAttempts
+	}
+	retryWaitMin := opts.RetryWaitMin
+	if retryWaitMin <= 0 {
+		retryWaitMin = defaultRetryWaitMin
+	}
+	retryWaitMax := opts.RetryWaitMax
+	if retryWaitMax < retryWaitMin {
+		retryWaitMax = max(defaultRetryWaitMax, retryWaitMin)
+	}
+
+	c := &Client{
+		apiURL:           apiURL,
+		audience:         audience,
+		idpURL:           parsedIdentityProviderURL,
+		clientID:         clientID,
+		clientSecret:     opts.ClientSecret,
+		tokenStore:       tokenStore,
+		refreshToken:     opts.RefreshToken,
+		oidcToken:        opts.OIDCToken,
+		oidcTokenFile:    opts.OIDCTokenFile,
+		jwks:             &jwksCache{},
+		httpClient:       httpClient,
+		userAgent:        userAgent,
+		retryMaxAttempts: retryMaxAttempts,
+		retryWaitMin:     retryWaitMin,
+		retryWaitMax:     retryWaitMax,
+	}
+	c.api = escapi.NewClient(apiDoer{client: c})
+
+	return c, nil
+}
+
+// API returns the client generated from the API's OpenAPI description, for
+// operations and fields which the methods of Client don't cover
+func (c *Client) API() *escapi.Client {
+	return c.api
 }
 
-func (c *Client) addAuthorizationHeader(req *http.Request) diag.Diagnostics {
-	token, err := c.accessToken(false)
+func (c *Client) addAuthorizationHeader(req *http.Request) error {
+	token, err := c.accessToken(req.Context(), false)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error obtaining access token: %w", err))
+		return fmt.Errorf("error obtaining access token: %w", err)
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
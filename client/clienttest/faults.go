@@ -0,0 +1,103 @@
+package clienttest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// Fault alters the responses to matching requests, e.g. to throttle them or
+// fail them with a server error
+type Fault struct {
+	// Method of the requests affected, any when empty
+	Method string
+	// Prefix of the paths of the requests affected, any when empty
+	PathPrefix string
+	// Status to answer with instead of handling the request. When zero, the
+	// request is handled normally after Delay.
+	StatusCode int
+	// Sent as Retry-After with the StatusCode, when set
+	RetryAfter time.Duration
+	// Wait before answering
+	Delay time.Duration
+	// Number of requests affected, all of them when zero
+	Times int
+}
+
+// InjectFault makes the server misbehave for matching requests. Faults are
+// matched in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults makes the server behave again
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// matchFault returns the fault to apply to r, if any, counting it against
+// the fault's Times. The caller must hold s.mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.PathPrefix) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+func writeFault(w http.ResponseWriter, fault *Fault) {
+	if fault.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(fault.RetryAfter.Seconds()))))
+	}
+	writeProblem(w, fault.StatusCode, http.StatusText(fault.StatusCode), "injected fault", nil)
+}
+
+// SetStatus forces the status of the resource with the given ID, e.g. to
+// `defunct`, cancelling any transition in progress
+func (s *Server) SetStatus(id string, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.transitions, id)
+
+	if network, ok := s.networks[id]; ok {
+		network.Status = status
+	} else if peering, ok := s.peerings[id]; ok {
+		peering.Status = status
+	} else if acl, ok := s.acls[id]; ok {
+		acl.Status = status
+	} else if cluster, ok := s.clusters[id]; ok {
+		cluster.Status = status
+	} else if job, ok := s.jobs[id]; ok {
+		job.Status = status
+	} else if integration, ok := s.integrations[id]; ok {
+		integration.Status = client.IntegrationStatus(status)
+	} else {
+		return fmt.Errorf("no resource with ID %q", id)
+	}
+
+	return nil
+}
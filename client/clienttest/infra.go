@@ -0,0 +1,472 @@
+package clienttest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+var providers = []string{"aws", "gcp", "azure"}
+
+func (s *Server) registerInfra(mux *http.ServeMux) {
+	const prefix = "/infra/v1/organizations/{organizationId}/projects/{projectId}"
+
+	mux.HandleFunc("GET "+prefix+"/networks", s.listNetworks)
+	mux.HandleFunc("POST "+prefix+"/networks", s.createNetwork)
+	mux.HandleFunc("GET "+prefix+"/networks/{networkId}", s.getNetwork)
+	mux.HandleFunc("PUT "+prefix+"/networks/{networkId}", s.updateNetwork)
+	mux.HandleFunc("DELETE "+prefix+"/networks/{networkId}", s.deleteNetwork)
+
+	mux.HandleFunc("GET "+prefix+"/peerings", s.listPeerings)
+	mux.HandleFunc("POST "+prefix+"/peerings", s.createPeering)
+	mux.HandleFunc("GET "+prefix+"/peerings/{peeringId}", s.getPeering)
+	mux.HandleFunc("PUT "+prefix+"/peerings/{peeringId}", s.updatePeering)
+	mux.HandleFunc("DELETE "+prefix+"/peerings/{peeringId}", s.deletePeering)
+
+	mux.HandleFunc("GET "+prefix+"/acls", s.listAcls)
+	mux.HandleFunc("POST "+prefix+"/acls", s.createAcl)
+	mux.HandleFunc("GET "+prefix+"/acls/{aclId}", s.getAcl)
+	mux.HandleFunc("PUT "+prefix+"/acls/{aclId}", s.updateAcl)
+	mux.HandleFunc("DELETE "+prefix+"/acls/{aclId}", s.deleteAcl)
+}
+
+// network returns the network named in the path, answering with a 404 when
+// it doesn't exist. The caller must hold s.mu.
+func (s *Server) network(w http.ResponseWriter, r *http.Request) (*client.Network, bool) {
+	if !s.checkProject(w, r) {
+		return nil, false
+	}
+
+	networkID := r.PathValue("networkId")
+	network, ok := s.networks[networkID]
+	if !ok || network.ProjectID != r.PathValue("projectId") {
+		writeNotFound(w, "network", networkID)
+		return nil, false
+	}
+	return network, true
+}
+
+func (s *Server) listNetworks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	result := client.ListNetworksResponse{Networks: []client.Network{}}
+	for id, network := range s.networks {
+		if network.ProjectID == r.PathValue("projectId") {
+			s.advance(id)
+			result.Networks = append(result.Networks, *network)
+		}
+	}
+	slices.SortFunc(result.Networks, func(a, b client.Network) int { return strings.Compare(a.NetworkID, b.NetworkID) })
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createNetwork(w http.ResponseWriter, r *http.Request) {
+	var request escapi.CreateNetworkRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	// The API accepts providers in any case, but always reports them in lower
+	// case
+	provider := strings.ToLower(request.Provider)
+
+	v := validation{}
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	v.require("provider", slices.Contains(providers, provider), fmt.Sprintf("provider must be one of %v", providers))
+	v.require("region", request.Region != "", "region is required")
+	v.require("cidrBlock", request.CidrBlock != "" || request.PublicAccess, "cidrBlock is required for private networks")
+	if v.failed(w) {
+		return
+	}
+
+	network := &client.Network{
+		NetworkID:    newID(),
+		ProjectID:    r.PathValue("projectId"),
+		Provider:     provider,
+		Region:       request.Region,
+		CIDRBlock:    request.CidrBlock,
+		Name:         request.Description,
+		Status:       "provisioning",
+		PublicAccess: request.PublicAccess,
+	}
+	s.networks[network.NetworkID] = network
+	s.startTransition(network.NetworkID, func() { network.Status = "available" })
+
+	writeJSON(w, http.StatusCreated, client.CreateNetworkResponse{NetworkID: network.NetworkID})
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	network, ok := s.network(w, r)
+	if !ok {
+		return
+	}
+	s.advance(network.NetworkID)
+
+	writeJSON(w, http.StatusOK, client.GetNetworkResponse{Network: *network})
+}
+
+func (s *Server) updateNetwork(w http.ResponseWriter, r *http.Request) {
+	var request escapi.UpdateNetworkRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	network, ok := s.network(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	if v.failed(w) {
+		return
+	}
+
+	network.Name = request.Description
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	network, ok := s.network(w, r)
+	if !ok {
+		return
+	}
+
+	for _, cluster := range s.clusters {
+		if cluster.NetworkID == network.NetworkID && cluster.Status != client.StateDeleted {
+			writeProblem(w, http.StatusConflict, "Conflict", "the network is used by managed clusters", nil)
+			return
+		}
+	}
+	for _, peering := range s.peerings {
+		if peering.NetworkID == network.NetworkID && peering.Status != client.StateDeleted {
+			writeProblem(w, http.StatusConflict, "Conflict", "the network has peerings", nil)
+			return
+		}
+	}
+
+	network.Status = "deleting"
+	s.startTransition(network.NetworkID, func() { network.Status = client.StateDeleted })
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// peering returns the peering named in the path, answering with a 404 when
+// it doesn't exist. The caller must hold s.mu.
+func (s *Server) peering(w http.ResponseWriter, r *http.Request) (*client.Peering, bool) {
+	if !s.checkProject(w, r) {
+		return nil, false
+	}
+
+	peeringID := r.PathValue("peeringId")
+	peering, ok := s.peerings[peeringID]
+	if !ok || peering.ProjectID != r.PathValue("projectId") {
+		writeNotFound(w, "peering", peeringID)
+		return nil, false
+	}
+	return peering, true
+}
+
+func (s *Server) listPeerings(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	result := escapi.ListPeeringsResponse{Peerings: []client.Peering{}}
+	for id, peering := range s.peerings {
+		if peering.ProjectID == r.PathValue("projectId") {
+			s.advance(id)
+			result.Peerings = append(result.Peerings, *peering)
+		}
+	}
+	slices.SortFunc(result.Peerings, func(a, b client.Peering) int { return strings.Compare(a.PeeringID, b.PeeringID) })
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createPeering(w http.ResponseWriter, r *http.Request) {
+	var request escapi.CreatePeeringRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	network, networkFound := s.networks[request.NetworkId]
+	networkFound = networkFound && network.ProjectID == r.PathValue("projectId") && network.Status != client.StateDeleted
+
+	v := validation{}
+	v.require("networkId", networkFound, "network not found")
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	v.require("peerAccountId", request.PeerAccountId != "", "peerAccountId is required")
+	v.require("peerNetworkId", request.PeerNetworkId != "", "peerNetworkId is required")
+	v.require("peerNetworkRegion", request.PeerNetworkRegion != "", "peerNetworkRegion is required")
+	v.require("routes", len(request.Routes) > 0, "at least one route is required")
+	if v.failed(w) {
+		return
+	}
+
+	peering := &client.Peering{
+		ProjectID:               r.PathValue("projectId"),
+		PeeringID:               newID(),
+		NetworkID:               network.NetworkID,
+		Provider:                network.Provider,
+		Name:                    request.Description,
+		PeerAccountIdentifier:   request.PeerAccountId,
+		PeerNetworkIdentifier:   request.PeerNetworkId,
+		PeerNetworkRegion:       request.PeerNetworkRegion,
+		ProviderPeeringMetadata: map[string]string{},
+		Routes:                  request.Routes,
+		Status:                  "provisioning",
+		Created:                 timestamp(),
+	}
+	s.peerings[peering.PeeringID] = peering
+	s.startTransition(peering.PeeringID, func() {
+		peering.Status = "initiated"
+		peering.ProviderPeeringMetadata = providerPeeringMetadata(peering.Provider)
+	})
+
+	writeJSON(w, http.StatusCreated, client.CreatePeeringResponse{PeeringID: peering.PeeringID})
+}
+
+// providerPeeringMetadata returns what the cloud provider reports once the
+// peering has been initiated, as needed to accept it on the peer's side
+func providerPeeringMetadata(provider string) map[string]string {
+	switch provider {
+	case "aws":
+		return map[string]string{"peeringLinkId": "pcx-" + newID()[:17]}
+	case "gcp":
+		return map[string]string{"projectId": "esc-" + newID()[:8], "networkId": "network-" + newID()[:8]}
+	}
+	return map[string]string{}
+}
+
+func (s *Server) getPeering(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peering, ok := s.peering(w, r)
+	if !ok {
+		return
+	}
+	s.advance(peering.PeeringID)
+
+	writeJSON(w, http.StatusOK, client.GetPeeringResponse{Peering: *peering})
+}
+
+func (s *Server) updatePeering(w http.ResponseWriter, r *http.Request) {
+	var request escapi.UpdatePeeringRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peering, ok := s.peering(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	if v.failed(w) {
+		return
+	}
+
+	peering.Name = request.Description
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deletePeering(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peering, ok := s.peering(w, r)
+	if !ok {
+		return
+	}
+
+	peering.Status = "deleting"
+	s.startTransition(peering.PeeringID, func() { peering.Status = client.StateDeleted })
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// acl returns the ACL named in the path, answering with a 404 when it
+// doesn't exist. The caller must hold s.mu.
+func (s *Server) acl(w http.ResponseWriter, r *http.Request) (*client.Acl, bool) {
+	if !s.checkProject(w, r) {
+		return nil, false
+	}
+
+	aclID := r.PathValue("aclId")
+	acl, ok := s.acls[aclID]
+	if !ok || acl.ProjectID != r.PathValue("projectId") {
+		writeNotFound(w, "ACL", aclID)
+		return nil, false
+	}
+	return acl, true
+}
+
+func validateCidrBlocks(v validation, cidrBlocks []client.AclCidrBlock) {
+	v.require("cidrBlocks", len(cidrBlocks) > 0, "at least one CIDR block is required")
+	for i, cidrBlock := range cidrBlocks {
+		v.require(fmt.Sprintf("cidrBlocks[%d].address", i), cidrBlock.Address != "", "address is required")
+	}
+}
+
+func (s *Server) listAcls(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	result := escapi.ListAclsResponse{Acls: []client.Acl{}}
+	for id, acl := range s.acls {
+		if acl.ProjectID == r.PathValue("projectId") {
+			s.advance(id)
+			result.Acls = append(result.Acls, *acl)
+		}
+	}
+	slices.SortFunc(result.Acls, func(a, b client.Acl) int { return strings.Compare(a.AclID, b.AclID) })
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createAcl(w http.ResponseWriter, r *http.Request) {
+	var request escapi.CreateAclRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	v := validation{}
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	validateCidrBlocks(v, request.CidrBlocks)
+	if v.failed(w) {
+		return
+	}
+
+	acl := &client.Acl{
+		AclID:          newID(),
+		OrganizationID: r.PathValue("organizationId"),
+		ProjectID:      r.PathValue("projectId"),
+		CidrBlocks:     request.CidrBlocks,
+		Created:        timestamp(),
+		Name:           request.Description,
+		Status:         "provisioning",
+		Updated:        timestamp(),
+	}
+	s.acls[acl.AclID] = acl
+	s.startTransition(acl.AclID, func() { acl.Status = "available" })
+
+	writeJSON(w, http.StatusCreated, client.CreateAclResponse{AclID: acl.AclID})
+}
+
+func (s *Server) getAcl(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acl, ok := s.acl(w, r)
+	if !ok {
+		return
+	}
+	s.advance(acl.AclID)
+
+	writeJSON(w, http.StatusOK, client.GetAclResponse{Acl: *acl})
+}
+
+func (s *Server) updateAcl(w http.ResponseWriter, r *http.Request) {
+	var request escapi.UpdateAclRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acl, ok := s.acl(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	if request.CidrBlocks != nil {
+		validateCidrBlocks(v, request.CidrBlocks)
+	}
+	if v.failed(w) {
+		return
+	}
+
+	if request.CidrBlocks != nil {
+		acl.CidrBlocks = request.CidrBlocks
+	}
+	if request.Description != "" {
+		acl.Name = request.Description
+	}
+	acl.Updated = timestamp()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteAcl(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	acl, ok := s.acl(w, r)
+	if !ok {
+		return
+	}
+
+	// ACLs hold no infrastructure, so unlike other resources they are gone
+	// as soon as the API accepts the deletion
+	acl.Status = client.StateDeleted
+	delete(s.transitions, acl.AclID)
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,187 @@
+package clienttest
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+// Data values which are write-only. The API only returns a masked copy,
+// suffixed with `Display`.
+var secretIntegrationData = []string{"accessKeyId", "apiKey", "secretAccessKey", "token"}
+
+func (s *Server) registerIntegrate(mux *http.ServeMux) {
+	const prefix = "/integrate/v1/organizations/{organizationId}/projects/{projectId}/integrations"
+
+	mux.HandleFunc("GET "+prefix, s.listIntegrations)
+	mux.HandleFunc("POST "+prefix, s.createIntegration)
+	mux.HandleFunc("GET "+prefix+"/{integrationId}", s.getIntegration)
+	mux.HandleFunc("PUT "+prefix+"/{integrationId}", s.updateIntegration)
+	mux.HandleFunc("DELETE "+prefix+"/{integrationId}", s.deleteIntegration)
+}
+
+// integration returns the integration named in the path, answering with a
+// 404 when it doesn't exist. The caller must hold s.mu.
+func (s *Server) integration(w http.ResponseWriter, r *http.Request) (*client.Integration, bool) {
+	if !s.checkProject(w, r) {
+		return nil, false
+	}
+
+	integrationID := r.PathValue("integrationId")
+	integration, ok := s.integrations[integrationID]
+	if !ok || integration.ProjectId != r.PathValue("projectId") {
+		writeNotFound(w, "integration", integrationID)
+		return nil, false
+	}
+	return integration, true
+}
+
+// masked returns the integration as the API shows it, without its secrets
+func masked(integration *client.Integration) client.Integration {
+	result := *integration
+	result.Data = maps.Clone(integration.Data)
+	for _, key := range secretIntegrationData {
+		value, ok := result.Data[key].(string)
+		if !ok {
+			continue
+		}
+		delete(result.Data, key)
+		if len(value) > 4 {
+			value = value[len(value)-4:]
+		}
+		result.Data[key+"Display"] = "****" + value
+	}
+	return result
+}
+
+func (s *Server) listIntegrations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	result := client.ListIntegrationsResponse{Integrations: []client.Integration{}}
+	for id, integration := range s.integrations {
+		if integration.ProjectId == r.PathValue("projectId") {
+			s.advance(id)
+			result.Integrations = append(result.Integrations, masked(integration))
+		}
+	}
+	slices.SortFunc(result.Integrations, func(a, b client.Integration) int { return a.Created.Compare(b.Created) })
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createIntegration(w http.ResponseWriter, r *http.Request) {
+	var request escapi.CreateIntegrationRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	sink, _ := request.Data["sink"].(string)
+
+	v := validation{}
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	v.require("data.sink", sink != "", "sink is required")
+	if v.failed(w) {
+		return
+	}
+
+	now := time.Now().UTC()
+	integration := &client.Integration{
+		Created:        now,
+		Data:           request.Data,
+		Description:    request.Description,
+		Id:             newID(),
+		OrganizationId: r.PathValue("organizationId"),
+		ProjectId:      r.PathValue("projectId"),
+		Status:         client.ACTIVE,
+		Updated:        now,
+	}
+	s.integrations[integration.Id] = integration
+
+	writeJSON(w, http.StatusCreated, client.CreateIntegrationResponse{Id: integration.Id})
+}
+
+func (s *Server) getIntegration(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	integration, ok := s.integration(w, r)
+	if !ok {
+		return
+	}
+	s.advance(integration.Id)
+
+	writeJSON(w, http.StatusOK, client.GetIntegrationResponse{Integration: masked(integration)})
+}
+
+// updateIntegration merges the data given into that of the integration, so
+// that secrets may be left out when they don't change
+func (s *Server) updateIntegration(w http.ResponseWriter, r *http.Request) {
+	var request escapi.UpdateIntegrationRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	integration, ok := s.integration(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	if request.Description != nil {
+		v.require("description", strings.TrimSpace(*request.Description) != "", "description is required")
+	}
+	if v.failed(w) {
+		return
+	}
+
+	if request.Description != nil {
+		integration.Description = *request.Description
+	}
+	if request.Data != nil {
+		maps.Copy(integration.Data, *request.Data)
+	}
+	integration.Updated = time.Now().UTC()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteIntegration(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	integration, ok := s.integration(w, r)
+	if !ok {
+		return
+	}
+
+	integration.Status = "deleting"
+	s.startTransition(integration.Id, func() {
+		if s.config.RemoveDeleted {
+			delete(s.integrations, integration.Id)
+		} else {
+			integration.Status = client.DELETED
+		}
+	})
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,328 @@
+package clienttest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+var (
+	topologies       = []string{"single-node", "three-node-multi-zone"}
+	projectionLevels = []string{"off", "system", "user"}
+)
+
+func (s *Server) registerMesdb(mux *http.ServeMux) {
+	const prefix = "/mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters"
+
+	mux.HandleFunc("GET "+prefix, s.listClusters)
+	mux.HandleFunc("POST "+prefix, s.createCluster)
+	mux.HandleFunc("GET "+prefix+"/{clusterId}", s.getCluster)
+	mux.HandleFunc("PUT "+prefix+"/{clusterId}", s.updateCluster)
+	mux.HandleFunc("DELETE "+prefix+"/{clusterId}", s.deleteCluster)
+	mux.HandleFunc("PUT "+prefix+"/{clusterId}/commands/resize", s.resizeCluster)
+	mux.HandleFunc("PUT "+prefix+"/{clusterId}/commands/upgrade", s.upgradeCluster)
+	mux.HandleFunc("PUT "+prefix+"/{clusterId}/disk/expand", s.expandClusterDisk)
+	mux.HandleFunc("GET "+prefix+"/{clusterId}/initialCredentials", s.getClusterInitialCredentials)
+}
+
+// cluster returns the managed cluster named in the path, answering with a 404
+// when it doesn't exist. The caller must hold s.mu.
+func (s *Server) cluster(w http.ResponseWriter, r *http.Request) (*client.ManagedCluster, bool) {
+	if !s.checkProject(w, r) {
+		return nil, false
+	}
+
+	clusterID := r.PathValue("clusterId")
+	cluster, ok := s.clusters[clusterID]
+	if !ok || cluster.ProjectID != r.PathValue("projectId") {
+		writeNotFound(w, "cluster", clusterID)
+		return nil, false
+	}
+	return cluster, true
+}
+
+// changeCluster starts a command on the cluster, which is unavailable until
+// it completes. Only available clusters accept commands. The caller must
+// hold s.mu.
+func (s *Server) changeCluster(w http.ResponseWriter, cluster *client.ManagedCluster, status string, complete func()) bool {
+	if cluster.Status != "available" {
+		writeProblem(w, http.StatusConflict, "Conflict", fmt.Sprintf("cluster is %s", cluster.Status), nil)
+		return false
+	}
+
+	cluster.Status = status
+	s.startTransition(cluster.ClusterID, func() {
+		complete()
+		cluster.Status = "available"
+	})
+	return true
+}
+
+func (s *Server) listClusters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	result := escapi.ListManagedClustersResponse{ManagedClusters: []client.ManagedCluster{}}
+	for id, cluster := range s.clusters {
+		if cluster.ProjectID == r.PathValue("projectId") {
+			s.advance(id)
+			result.ManagedClusters = append(result.ManagedClusters, *cluster)
+		}
+	}
+	slices.SortFunc(result.ManagedClusters, func(a, b client.ManagedCluster) int { return strings.Compare(a.ClusterID, b.ClusterID) })
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createCluster(w http.ResponseWriter, r *http.Request) {
+	var request escapi.CreateManagedClusterRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	network, networkFound := s.networks[request.NetworkId]
+	networkFound = networkFound && network.ProjectID == r.PathValue("projectId") && network.Status != client.StateDeleted
+	_, aclFound := s.acls[request.AclId]
+
+	// Like the API, accept sizes, disk types, versions and projection levels
+	// in any case but report them in lower case
+	request.InstanceType = strings.ToLower(request.InstanceType)
+	request.DiskType = strings.ToLower(request.DiskType)
+	request.ServerVersion = strings.ToLower(request.ServerVersion)
+	request.ProjectionLevel = strings.ToLower(request.ProjectionLevel)
+
+	v := validation{}
+	v.require("networkId", networkFound, "network not found")
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	v.require("topology", slices.Contains(topologies, request.Topology), fmt.Sprintf("topology must be one of %v", topologies))
+	v.require("instanceType", request.InstanceType != "", "instanceType is required")
+	v.require("diskSizeGb", request.DiskSizeGB >= 8, "diskSizeGb must be at least 8")
+	v.require("diskType", request.DiskType != "", "diskType is required")
+	v.require("serverVersion", request.ServerVersion != "", "serverVersion is required")
+	v.require("projectionLevel", slices.Contains(projectionLevels, request.ProjectionLevel), fmt.Sprintf("projectionLevel must be one of %v", projectionLevels))
+	v.require("aclId", request.AclId == "" || aclFound, "ACL not found")
+	if v.failed(w) {
+		return
+	}
+
+	cluster := &client.ManagedCluster{
+		OrganizationID:   r.PathValue("organizationId"),
+		ProjectID:        r.PathValue("projectId"),
+		NetworkID:        network.NetworkID,
+		ClusterID:        newID(),
+		Name:             request.Description,
+		Provider:         network.Provider,
+		Region:           network.Region,
+		Topology:         request.Topology,
+		InstanceType:     request.InstanceType,
+		DiskSizeGB:       request.DiskSizeGB,
+		DiskType:         request.DiskType,
+		DiskIops:         request.DiskIops,
+		DiskThroughput:   request.DiskThroughput,
+		ServerVersion:    request.ServerVersion,
+		ServerVersionTag: request.ServerVersion + ".0",
+		ProjectionLevel:  request.ProjectionLevel,
+		Status:           "provisioning",
+		Created:          timestamp(),
+		Protected:        request.Protected,
+		AclId:            request.AclId,
+		PublicAccess:     request.PublicAccess,
+	}
+	s.clusters[cluster.ClusterID] = cluster
+	s.credentials[cluster.ClusterID] = &client.GetManagedClusterInitialCredentialsResponse{
+		AdminPassword: newID(),
+		OpsPassword:   newID(),
+		GeneratedAt:   timestamp(),
+		ClusterID:     cluster.ClusterID,
+	}
+	s.startTransition(cluster.ClusterID, func() { cluster.Status = "available" })
+
+	writeJSON(w, http.StatusCreated, client.CreateManagedClusterResponse{ClusterID: cluster.ClusterID})
+}
+
+func (s *Server) getCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.cluster(w, r)
+	if !ok {
+		return
+	}
+	s.advance(cluster.ClusterID)
+
+	writeJSON(w, http.StatusOK, client.GetManagedClusterResponse{ManagedCluster: *cluster})
+}
+
+func (s *Server) updateCluster(w http.ResponseWriter, r *http.Request) {
+	var request escapi.UpdateManagedClusterRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.cluster(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	if v.failed(w) {
+		return
+	}
+
+	cluster.Name = request.Description
+	cluster.Protected = request.Protected
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.cluster(w, r)
+	if !ok {
+		return
+	}
+
+	if cluster.Protected {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", "cluster is protected from deletion", nil)
+		return
+	}
+
+	cluster.Status = "deleting"
+	s.startTransition(cluster.ClusterID, func() { cluster.Status = client.StateDeleted })
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) resizeCluster(w http.ResponseWriter, r *http.Request) {
+	var request escapi.ResizeManagedClusterRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.cluster(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	v.require("targetSize", request.TargetSize != "", "targetSize is required")
+	if v.failed(w) {
+		return
+	}
+
+	if s.changeCluster(w, cluster, "resizing", func() { cluster.InstanceType = strings.ToLower(request.TargetSize) }) {
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (s *Server) upgradeCluster(w http.ResponseWriter, r *http.Request) {
+	var request escapi.UpgradeManagedClusterRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.cluster(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	v.require("targetTag", request.TargetTag != "", "targetTag is required")
+	if v.failed(w) {
+		return
+	}
+
+	if s.changeCluster(w, cluster, "upgrading", func() {
+		cluster.ServerVersionTag = strings.ToLower(request.TargetTag)
+		if parts := strings.SplitN(cluster.ServerVersionTag, ".", 3); len(parts) >= 2 {
+			cluster.ServerVersion = parts[0] + "." + parts[1]
+		}
+	}) {
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (s *Server) expandClusterDisk(w http.ResponseWriter, r *http.Request) {
+	var request escapi.ExpandManagedClusterDiskRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.cluster(w, r)
+	if !ok {
+		return
+	}
+
+	v := validation{}
+	v.require("diskSizeGb", request.DiskSizeGB >= cluster.DiskSizeGB, "disks can only be expanded")
+	v.require("diskType", request.DiskType != "", "diskType is required")
+	if v.failed(w) {
+		return
+	}
+
+	if s.changeCluster(w, cluster, "expanding", func() {
+		cluster.DiskSizeGB = request.DiskSizeGB
+		cluster.DiskType = strings.ToLower(request.DiskType)
+		cluster.DiskIops = request.DiskIops
+		cluster.DiskThroughput = request.DiskThroughput
+	}) {
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (s *Server) getClusterInitialCredentials(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.cluster(w, r)
+	if !ok {
+		return
+	}
+
+	credentials, ok := s.credentials[cluster.ClusterID]
+	if !ok {
+		writeProblem(w, http.StatusPreconditionFailed, "Precondition Failed", "initial credentials have been cleared", nil)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, credentials)
+}
+
+// ClearInitialCredentials forgets the initial credentials of a managed
+// cluster, as happens some time after it has been created
+func (s *Server) ClearInitialCredentials(clusterID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.credentials, clusterID)
+}
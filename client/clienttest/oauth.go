@@ -0,0 +1,328 @@
+package clienttest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+// Audience of the access tokens issued, as expected by the client
+const apiAudience = "https://api.eventstore.cloud"
+
+// ID of the key signing access tokens
+const signingKeyID = "clienttest"
+
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	grantTypeDeviceCode    = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// issuer signs access tokens with a key generated for the server, and
+// publishes it the way the real identity provider does
+type issuer struct {
+	url       string
+	lifetime  time.Duration
+	claims    map[string]interface{}
+	algorithm jwa.SignatureAlgorithm
+	keyID     string
+	key       *rsa.PrivateKey
+	keys      jwk.Set
+
+	mu sync.Mutex
+	// Subjects of the tokens issued
+	issued map[string]string
+}
+
+func newIssuer(serverURL string, config *Config) *issuer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	publicKey, err := jwk.New(rsaKey.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	_ = publicKey.Set(jwk.KeyIDKey, signingKeyID)
+	_ = publicKey.Set(jwk.AlgorithmKey, jwa.RS256)
+	keys := jwk.NewSet()
+	keys.Add(publicKey)
+
+	i := &issuer{
+		url:       serverURL + "/",
+		lifetime:  config.TokenLifetime,
+		claims:    config.TokenClaims,
+		algorithm: config.TokenAlgorithm,
+		keyID:     config.TokenKeyID,
+		key:       rsaKey,
+		keys:      keys,
+		issued:    map[string]string{},
+	}
+	if i.algorithm == "" {
+		i.algorithm = jwa.RS256
+	}
+	if i.keyID == "" {
+		i.keyID = signingKeyID
+	}
+	return i
+}
+
+func (i *issuer) issue(subject string) (string, error) {
+	now := time.Now()
+
+	token := jwt.New()
+	_ = token.Set(jwt.IssuerKey, i.url)
+	_ = token.Set(jwt.SubjectKey, subject)
+	_ = token.Set(jwt.AudienceKey, []string{apiAudience})
+	_ = token.Set(jwt.IssuedAtKey, now)
+	_ = token.Set(jwt.ExpirationKey, now.Add(i.lifetime))
+	_ = token.Set(jwt.JwtIDKey, newID())
+	for name, value := range i.claims {
+		_ = token.Set(name, value)
+	}
+
+	signed, err := i.sign(token)
+	if err != nil {
+		return "", err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.issued[string(signed)] = subject
+
+	return string(signed), nil
+}
+
+// sign signs token with the configured algorithm and key ID, which are only
+// those the client accepts by default
+func (i *issuer) sign(token jwt.Token) ([]byte, error) {
+	headers := jws.NewHeaders()
+	_ = headers.Set(jws.KeyIDKey, i.keyID)
+
+	var key interface{} = i.key
+	switch {
+	case i.algorithm == jwa.NoSignature:
+		// Unsupported by jws.Sign, as no token should be left unsigned
+		_ = headers.Set(jws.AlgorithmKey, jwa.NoSignature)
+		header, err := json.Marshal(headers)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := json.Marshal(token)
+		if err != nil {
+			return nil, err
+		}
+		encoding := base64.RawURLEncoding
+		return []byte(encoding.EncodeToString(header) + "." + encoding.EncodeToString(payload) + "."), nil
+	case strings.HasPrefix(i.algorithm.String(), "HS"):
+		key = []byte("clienttest-shared-secret")
+	}
+
+	return jwt.Sign(token, i.algorithm, key, jwt.WithHeaders(headers))
+}
+
+// subject returns the subject of token, and whether it was issued by the
+// server and hasn't expired
+func (i *issuer) subject(token string) (string, bool) {
+	i.mu.Lock()
+	subject, issued := i.issued[token]
+	i.mu.Unlock()
+	if !issued {
+		return "", false
+	}
+
+	parsed, err := jwt.ParseString(token)
+	return subject, err == nil && time.Now().Before(parsed.Expiration())
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, code string, description string) {
+	writeJSON(w, status, oauthError{Code: code, Description: description})
+}
+
+func (s *Server) registerIdentityProvider(mux *http.ServeMux) {
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"issuer":         s.issuer.url,
+			"jwks_uri":       s.URL + "/.well-known/jwks.json",
+			"token_endpoint": s.URL + "/oauth/token",
+		})
+	})
+
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.issuer.keys)
+	})
+
+	mux.HandleFunc("POST /oauth/device/code", s.deviceCode)
+	mux.HandleFunc("POST /oauth/token", s.token)
+}
+
+// deviceLogin is a device authorization awaiting approval
+type deviceLogin struct {
+	clientID string
+	// Errors left to answer polls with, from Config.DeviceLoginErrors
+	errors []string
+}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceCode starts a device login, as in RFC 8628 section 3.1
+func (s *Server) deviceCode(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	clientID := r.PostForm.Get("client_id")
+	if clientID == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "client_id is required")
+		return
+	}
+
+	deviceCode := newID()
+	userCode := strings.ToUpper(newID()[:8])
+
+	s.mu.Lock()
+	s.deviceLogins[deviceCode] = &deviceLogin{
+		clientID: clientID,
+		errors:   slices.Clone(s.config.DeviceLoginErrors),
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, deviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         s.URL + "/activate",
+		VerificationURIComplete: s.URL + "/activate?user_code=" + userCode,
+		ExpiresIn:               600,
+		Interval:                1,
+	})
+}
+
+// pollDeviceLogin answers a poll of the device login with the next error
+// configured, or approves it. The caller must hold s.mu.
+func (s *Server) pollDeviceLogin(deviceCode string, clientID string) (string, bool) {
+	login, ok := s.deviceLogins[deviceCode]
+	if !ok || login.clientID != clientID {
+		return "invalid_grant", false
+	}
+	if len(login.errors) == 0 {
+		delete(s.deviceLogins, deviceCode)
+		return "", true
+	}
+
+	code := login.errors[0]
+	login.errors = login.errors[1:]
+	if code == "access_denied" || code == "expired_token" {
+		delete(s.deviceLogins, deviceCode)
+	}
+	return code, false
+}
+
+// token implements the grants used by the client: refresh token, client
+// credentials, device code and token exchange. Unless Config.SubjectTokens is set, any
+// non-empty subject token is accepted for the latter.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	clientID := r.PostForm.Get("client_id")
+	if clientID == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "client_id is required")
+		return
+	}
+
+	var subject, refreshToken string
+	switch r.PostForm.Get("grant_type") {
+	case "refresh_token":
+		s.mu.Lock()
+		known := slices.Contains(s.config.RefreshTokens, r.PostForm.Get("refresh_token"))
+		s.mu.Unlock()
+		if !known {
+			writeOAuthError(w, http.StatusForbidden, "invalid_grant", "unknown or invalid refresh token")
+			return
+		}
+		subject = "user|clienttest"
+	case grantTypeDeviceCode:
+		s.mu.Lock()
+		code, approved := s.pollDeviceLogin(r.PostForm.Get("device_code"), clientID)
+		if approved {
+			refreshToken = "device-" + newID()
+			s.config.RefreshTokens = append(s.config.RefreshTokens, refreshToken)
+		}
+		s.mu.Unlock()
+		if !approved {
+			writeOAuthError(w, http.StatusBadRequest, code, "")
+			return
+		}
+		subject = "user|clienttest"
+	case "client_credentials":
+		secret, ok := s.config.ClientSecrets[clientID]
+		if !ok || secret != r.PostForm.Get("client_secret") {
+			writeOAuthError(w, http.StatusUnauthorized, "access_denied", "unauthorized")
+			return
+		}
+		subject = clientID + "@clients"
+	case grantTypeTokenExchange:
+		subjectToken := r.PostForm.Get("subject_token")
+		if subjectToken == "" {
+			writeOAuthError(w, http.StatusBadRequest, "invalid_request", "subject_token is required")
+			return
+		}
+		if s.config.SubjectTokens != nil && !slices.Contains(s.config.SubjectTokens, subjectToken) {
+			writeOAuthError(w, http.StatusForbidden, "invalid_grant", "unknown or invalid subject token")
+			return
+		}
+		subject = "workload|clienttest"
+	default:
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "")
+		return
+	}
+
+	accessToken, err := s.issuer.issue(subject)
+	if err != nil {
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.config.TokenLifetime.Seconds()),
+		Scope:        "openid offline_access",
+	})
+}
@@ -0,0 +1,134 @@
+package clienttest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+func (s *Server) registerOrchestrate(mux *http.ServeMux) {
+	const prefix = "/orchestrate/v1/organizations/{organizationId}/projects/{projectId}/jobs"
+
+	mux.HandleFunc("GET "+prefix, s.listJobs)
+	mux.HandleFunc("POST "+prefix, s.createJob)
+	mux.HandleFunc("GET "+prefix+"/{jobId}", s.getJob)
+	mux.HandleFunc("DELETE "+prefix+"/{jobId}", s.deleteJob)
+}
+
+// job returns the job named in the path, answering with a 404 when it
+// doesn't exist. The caller must hold s.mu.
+func (s *Server) job(w http.ResponseWriter, r *http.Request) (*client.Job, bool) {
+	if !s.checkProject(w, r) {
+		return nil, false
+	}
+
+	jobID := r.PathValue("jobId")
+	job, ok := s.jobs[jobID]
+	if !ok || job.ProjectId != r.PathValue("projectId") {
+		writeNotFound(w, "job", jobID)
+		return nil, false
+	}
+	return job, true
+}
+
+func (s *Server) listJobs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	result := escapi.ListJobsResponse{Jobs: []client.Job{}}
+	for id, job := range s.jobs {
+		if job.ProjectId == r.PathValue("projectId") {
+			s.advance(id)
+			result.Jobs = append(result.Jobs, *job)
+		}
+	}
+	slices.SortFunc(result.Jobs, func(a, b client.Job) int { return strings.Compare(a.Id, b.Id) })
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// createJob accepts scheduled backups, the only kind of job the provider
+// manages
+func (s *Server) createJob(w http.ResponseWriter, r *http.Request) {
+	var request escapi.CreateJobRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	clusterID, _ := request.Data["clusterId"].(string)
+	cluster, clusterFound := s.clusters[clusterID]
+	clusterFound = clusterFound && cluster.ProjectID == r.PathValue("projectId") && cluster.Status != client.StateDeleted
+	maxBackupCount, _ := request.Data["maxBackupCount"].(float64)
+
+	v := validation{}
+	v.require("type", request.Type == "ScheduledBackup", "type must be ScheduledBackup")
+	v.require("description", strings.TrimSpace(request.Description) != "", "description is required")
+	v.require("schedule", request.Schedule != "", "schedule is required")
+	v.require("data.clusterId", clusterFound, "cluster not found")
+	v.require("data.maxBackupCount", maxBackupCount >= 1, "maxBackupCount must be at least 1")
+	if v.failed(w) {
+		return
+	}
+
+	job := &client.Job{
+		Data:           request.Data,
+		Description:    request.Description,
+		Id:             newID(),
+		OrganizationId: r.PathValue("organizationId"),
+		ProjectId:      r.PathValue("projectId"),
+		Schedule:       request.Schedule,
+		Status:         "active",
+		Type:           request.Type,
+	}
+	s.jobs[job.Id] = job
+
+	writeJSON(w, http.StatusCreated, client.CreateJobResponse{Id: job.Id})
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.job(w, r)
+	if !ok {
+		return
+	}
+	s.advance(job.Id)
+
+	writeJSON(w, http.StatusOK, client.GetJobResponse{Job: *job})
+}
+
+func (s *Server) deleteJob(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.job(w, r)
+	if !ok {
+		return
+	}
+
+	job.Status = "deleting"
+	s.startTransition(job.Id, func() {
+		if s.config.RemoveDeleted {
+			delete(s.jobs, job.Id)
+		} else {
+			job.Status = client.StateDeleted
+		}
+	})
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,337 @@
+package clienttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// Mode tells a Recorder whether to make real calls or replay recorded ones
+type Mode int
+
+const (
+	// ModeReplay answers calls from the cassette without touching the network
+	ModeReplay Mode = iota
+	// ModeRecord makes real calls and saves them to the cassette on Close
+	ModeRecord
+)
+
+// Headers of recorded responses which are kept. Everything else, such as
+// cookies and tracing headers, is dropped.
+var recordedHeaders = []string{"Content-Type", "Location", "Retry-After"}
+
+// Recorder records calls made to the Event Store Cloud API into a cassette
+// file, and replays them in later runs, so that tests can exercise the client
+// against real responses without network access. Secrets are scrubbed from
+// the bodies before they are saved.
+//
+// Cassettes may also be written by hand, in which case they only hold what
+// their author expected the API to return. Recorded cassettes carry the time
+// they were recorded, which tells them apart.
+//
+// Only calls to the API are recorded. Calls to other hosts, such as the
+// identity provider, go through to the wrapped transport in either mode,
+// which keeps tokens out of cassettes.
+//
+// Interactions are replayed in the order they were recorded. Each request
+// must match the recorded method, path and query, and its JSON body if any.
+type Recorder struct {
+	path   string
+	mode   Mode
+	apiURL *url.URL
+
+	mu           sync.Mutex
+	recordedAt   *time.Time
+	interactions []interaction
+	replayed     int
+}
+
+type cassette struct {
+	// Unset in cassettes written by hand
+	RecordedAt   *time.Time    `json:"recordedAt,omitempty"`
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	// Path and query of the request
+	URI  string          `json:"uri"`
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int               `json:"status"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	// JSON bodies are kept as they are for readability, others as text
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// NewRecorder returns a recorder for the cassette at path, recording or
+// replaying calls to the API at apiURL. Replaying requires the cassette to
+// exist.
+func NewRecorder(path string, mode Mode, apiURL string) (*Recorder, error) {
+	parsedURL, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL %q: %w", apiURL, err)
+	}
+
+	r := &Recorder{
+		path:   path,
+		mode:   mode,
+		apiURL: parsedURL,
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("error parsing cassette %s: %w", path, err)
+		}
+		r.recordedAt = c.RecordedAt
+		r.interactions = c.Interactions
+	}
+
+	return r, nil
+}
+
+// Recorded reports whether the replayed cassette was recorded from the API,
+// as opposed to written by hand. It is always true when recording.
+func (r *Recorder) Recorded() bool {
+	return r.mode == ModeRecord || r.recordedAt != nil
+}
+
+// Wrap returns a transport recording or replaying calls to the API, and
+// passing others on to next. It fits client.Config.WrapTransport.
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Scheme != r.apiURL.Scheme || req.URL.Host != r.apiURL.Host {
+			return next.RoundTrip(req)
+		}
+		if r.mode == ModeRecord {
+			return r.record(next, req)
+		}
+		return r.replay(req)
+	})
+}
+
+// Close saves the cassette when recording. When replaying, it reports
+// interactions which were never replayed, as the client made fewer calls
+// than when they were recorded.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		if r.replayed < len(r.interactions) {
+			next := r.interactions[r.replayed].Request
+			return fmt.Errorf(
+				"cassette %s: %d of %d interactions were not replayed, starting with %s %s",
+				r.path, len(r.interactions)-r.replayed, len(r.interactions), next.Method, next.URI,
+			)
+		}
+		return nil
+	}
+
+	recordedAt := time.Now().UTC().Truncate(time.Second)
+	data, err := json.MarshalIndent(cassette{RecordedAt: &recordedAt, Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	recorded, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	response := recordedResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    map[string]string{},
+	}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			response.Headers[name] = value
+		}
+	}
+	response.Body, response.Text = scrubBody(resp.Header.Get("Content-Type"), data)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, interaction{Request: *recorded, Response: response})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	actual, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.replayed >= len(r.interactions) {
+		return nil, fmt.Errorf("cassette %s: unexpected %s %s after the last recorded interaction", r.path, actual.Method, actual.URI)
+	}
+	recorded := r.interactions[r.replayed]
+	if err := recorded.Request.match(actual); err != nil {
+		return nil, fmt.Errorf("cassette %s, interaction %d: %w", r.path, r.replayed+1, err)
+	}
+	r.replayed++
+
+	body := []byte(recorded.Response.Text)
+	if recorded.Response.Body != nil {
+		body = recorded.Response.Body
+	}
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Response.StatusCode, http.StatusText(recorded.Response.StatusCode)),
+		StatusCode:    recorded.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+	for name, value := range recorded.Response.Headers {
+		resp.Header.Set(name, value)
+	}
+
+	return resp, nil
+}
+
+func newRecordedRequest(req *http.Request) (*recordedRequest, error) {
+	recorded := &recordedRequest{
+		Method: req.Method,
+		URI:    req.URL.RequestURI(),
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		recorded.Body, recorded.Text = scrubBody(req.Header.Get("Content-Type"), data)
+	}
+
+	return recorded, nil
+}
+
+// match checks that a request is the one recorded. JSON bodies are compared
+// by value, after scrubbing both.
+func (recorded recordedRequest) match(actual *recordedRequest) error {
+	if recorded.Method != actual.Method || recorded.URI != actual.URI {
+		return fmt.Errorf("expected %s %s, got %s %s", recorded.Method, recorded.URI, actual.Method, actual.URI)
+	}
+
+	if recorded.Text != actual.Text {
+		return fmt.Errorf("%s %s: expected body %q, got %q", actual.Method, actual.URI, recorded.Text, actual.Text)
+	}
+	if (recorded.Body == nil) != (actual.Body == nil) {
+		return fmt.Errorf("%s %s: expected body %s, got %s", actual.Method, actual.URI, recorded.Body, actual.Body)
+	}
+	if recorded.Body != nil {
+		var expectedValue, actualValue interface{}
+		if err := json.Unmarshal(recorded.Body, &expectedValue); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(actual.Body, &actualValue); err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(expectedValue, actualValue) {
+			return fmt.Errorf("%s %s: expected body %s, got %s", actual.Method, actual.URI, recorded.Body, actual.Body)
+		}
+	}
+
+	return nil
+}
+
+// scrubBody returns a JSON body with the values of secret fields replaced,
+// or any other body as text
+func scrubBody(contentType string, data []byte) (json.RawMessage, string) {
+	if len(data) == 0 {
+		return nil, ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var value interface{}
+	if (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) && json.Unmarshal(data, &value) == nil {
+		scrubbed, err := json.Marshal(scrubValue(value))
+		if err == nil {
+			return scrubbed, ""
+		}
+	}
+
+	return nil, string(data)
+}
+
+// The value replacing secrets in cassettes
+const scrubbed = "[SCRUBBED]"
+
+func scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if _, isString := field.(string); isString && client.IsSensitiveKey(key) {
+				v[key] = scrubbed
+			} else {
+				v[key] = scrubValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = scrubValue(item)
+		}
+	}
+	return value
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,214 @@
+package clienttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newRecorderTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/credentials":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "session=secret")
+			_, _ = io.WriteString(w, `{"adminPassword":"hunter2","nested":[{"secretAccessKey":"s3cret","port":2113}],"clusterId":"c1"}`)
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, "hello")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, c *http.Client, url string) (int, string) {
+	t.Helper()
+
+	resp, err := c.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	server := newRecorderTestServer(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "round_trip.json")
+
+	recorder, err := NewRecorder(path, ModeRecord, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: recorder.Wrap(http.DefaultTransport)}
+
+	// The caller sees the real response while recording
+	if _, body := get(t, c, server.URL+"/credentials?verbose=true"); !strings.Contains(body, "hunter2") {
+		t.Errorf("expected the unscrubbed body while recording, got %s", body)
+	}
+	get(t, c, server.URL+"/text")
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "s3cret", "session"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	// Replaying needs no server
+	server.Close()
+
+	recorder, err = NewRecorder(path, ModeReplay, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recorder.Recorded() {
+		t.Error("expected the cassette to be marked as recorded")
+	}
+	c = &http.Client{Transport: recorder.Wrap(http.DefaultTransport)}
+
+	status, body := get(t, c, server.URL+"/credentials?verbose=true")
+	if status != http.StatusOK {
+		t.Errorf("expected status 200, got %d", status)
+	}
+	expected := `{"adminPassword":"[SCRUBBED]","clusterId":"c1","nested":[{"port":2113,"secretAccessKey":"[SCRUBBED]"}]}`
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if compacted.String() != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+	if _, body := get(t, c, server.URL+"/text"); body != "hello" {
+		t.Errorf("expected hello, got %s", body)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecorderReplayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mismatch.json")
+	cassette := `{"interactions":[
+		{"request":{"method":"PUT","uri":"/things/1","body":{"name":"a","size":1}},"response":{"status":204}}
+	]}`
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder, err := NewRecorder(path, ModeReplay, "https://api.example.com"); err != nil {
+		t.Fatal(err)
+	} else if recorder.Recorded() {
+		t.Error("expected a cassette without a recording time to be hand-written")
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		uri    string
+		body   string
+		err    string
+	}{
+		{name: "other path", method: "PUT", uri: "/things/2", body: `{"name":"a","size":1}`, err: "expected PUT /things/1, got PUT /things/2"},
+		{name: "other method", method: "POST", uri: "/things/1", body: `{"name":"a","size":1}`, err: "expected PUT /things/1, got POST /things/1"},
+		{name: "other body", method: "PUT", uri: "/things/1", body: `{"name":"b","size":1}`, err: "expected body"},
+		{name: "same body reordered", method: "PUT", uri: "/things/1", body: `{"size":1, "name":"a"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, err := NewRecorder(path, ModeReplay, "https://api.example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &http.Client{Transport: recorder.Wrap(http.DefaultTransport)}
+
+			req, err := http.NewRequest(tt.method, "https://api.example.com"+tt.uri, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := c.Do(req)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusNoContent {
+					t.Errorf("expected status 204, got %d", resp.StatusCode)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRecorderUnreplayedInteractions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unreplayed.json")
+	cassette := `{"interactions":[
+		{"request":{"method":"GET","uri":"/a"},"response":{"status":200,"text":"a"}},
+		{"request":{"method":"GET","uri":"/b"},"response":{"status":200,"text":"b"}}
+	]}`
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder, err := NewRecorder(path, ModeReplay, "https://api.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: recorder.Wrap(http.DefaultTransport)}
+	get(t, c, "https://api.example.com/a")
+
+	err = recorder.Close()
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 interactions were not replayed, starting with GET /b") {
+		t.Errorf("expected an error about GET /b, got %v", err)
+	}
+}
+
+func TestRecorderPassesOtherHostsThrough(t *testing.T) {
+	server := newRecorderTestServer(t)
+
+	// Nothing is recorded for the server, so replaying would fail
+	path := filepath.Join(t.TempDir(), "empty.json")
+	if err := os.WriteFile(path, []byte(`{"interactions":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	recorder, err := NewRecorder(path, ModeReplay, "https://api.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: recorder.Wrap(http.DefaultTransport)}
+
+	if _, body := get(t, c, server.URL+"/text"); body != "hello" {
+		t.Errorf("expected hello, got %s", body)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,206 @@
+package clienttest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+func (s *Server) registerResources(mux *http.ServeMux) {
+	const prefix = "/resources/v1/organizations"
+
+	mux.HandleFunc("GET "+prefix, s.listOrganizations)
+	mux.HandleFunc("GET "+prefix+"/{organizationId}/projects", s.listProjects)
+	mux.HandleFunc("POST "+prefix+"/{organizationId}/projects", s.createProject)
+	mux.HandleFunc("GET "+prefix+"/{organizationId}/projects/{projectId}", s.getProject)
+	mux.HandleFunc("PUT "+prefix+"/{organizationId}/projects/{projectId}", s.updateProject)
+	mux.HandleFunc("DELETE "+prefix+"/{organizationId}/projects/{projectId}", s.deleteProject)
+}
+
+// checkOrganization answers with a 404 unless the organization in the path
+// exists. The caller must hold s.mu.
+func (s *Server) checkOrganization(w http.ResponseWriter, r *http.Request) bool {
+	organizationID := r.PathValue("organizationId")
+	if _, ok := s.organizations[organizationID]; !ok {
+		writeNotFound(w, "organization", organizationID)
+		return false
+	}
+	return true
+}
+
+// checkProject answers with a 404 unless the organization and project in the
+// path exist. The caller must hold s.mu.
+func (s *Server) checkProject(w http.ResponseWriter, r *http.Request) bool {
+	if !s.checkOrganization(w, r) {
+		return false
+	}
+
+	projectID := r.PathValue("projectId")
+	project, ok := s.projects[projectID]
+	if !ok || project.OrganizationID != r.PathValue("organizationId") {
+		writeNotFound(w, "project", projectID)
+		return false
+	}
+	return true
+}
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := client.ListOrganizationsResponse{Organizations: []client.Organization{}}
+	for _, organization := range s.organizations {
+		result.Organizations = append(result.Organizations, *organization)
+	}
+	sort.Slice(result.Organizations, func(i, j int) bool {
+		return result.Organizations[i].OrganizationID < result.Organizations[j].OrganizationID
+	})
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkOrganization(w, r) {
+		return
+	}
+
+	result := client.ListProjectsResponse{Projects: []client.Project{}}
+	for _, project := range s.projects {
+		if project.OrganizationID == r.PathValue("organizationId") {
+			result.Projects = append(result.Projects, *project)
+		}
+	}
+	sort.Slice(result.Projects, func(i, j int) bool {
+		return result.Projects[i].Created < result.Projects[j].Created
+	})
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var request escapi.CreateProjectRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkOrganization(w, r) {
+		return
+	}
+
+	v := validation{}
+	v.require("name", strings.TrimSpace(request.Name) != "", "name is required")
+	if v.failed(w) {
+		return
+	}
+
+	project := &client.Project{
+		ProjectID:      newID(),
+		OrganizationID: r.PathValue("organizationId"),
+		Name:           request.Name,
+		Created:        timestamp(),
+	}
+	s.projects[project.ProjectID] = project
+
+	writeJSON(w, http.StatusCreated, client.CreateProjectResponse{ProjectID: project.ProjectID})
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, client.GetProjectResponse{Project: *s.projects[r.PathValue("projectId")]})
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var request escapi.UpdateProjectRequest
+	if !decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	v := validation{}
+	v.require("name", strings.TrimSpace(request.Name) != "", "name is required")
+	if v.failed(w) {
+		return
+	}
+
+	s.projects[r.PathValue("projectId")].Name = request.Name
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// deleteProject refuses to delete projects which still hold resources, as
+// the API does
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkProject(w, r) {
+		return
+	}
+
+	projectID := r.PathValue("projectId")
+	if s.projectInUse(projectID) {
+		writeProblem(w, http.StatusConflict, "Conflict", "the project still contains resources", nil)
+		return
+	}
+
+	delete(s.projects, projectID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// projectInUse reports whether any resource which hasn't been deleted belongs
+// to the project. The caller must hold s.mu.
+func (s *Server) projectInUse(projectID string) bool {
+	for _, network := range s.networks {
+		if network.ProjectID == projectID && network.Status != client.StateDeleted {
+			return true
+		}
+	}
+	for _, peering := range s.peerings {
+		if peering.ProjectID == projectID && peering.Status != client.StateDeleted {
+			return true
+		}
+	}
+	for _, acl := range s.acls {
+		if acl.ProjectID == projectID && acl.Status != client.StateDeleted {
+			return true
+		}
+	}
+	for _, cluster := range s.clusters {
+		if cluster.ProjectID == projectID && cluster.Status != client.StateDeleted {
+			return true
+		}
+	}
+	for _, job := range s.jobs {
+		if job.ProjectId == projectID && job.Status != client.StateDeleted {
+			return true
+		}
+	}
+	for _, integration := range s.integrations {
+		if integration.ProjectId == projectID && integration.Status != client.DELETED {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,347 @@
+// Package clienttest provides an in-memory fake of the Event Store Cloud API
+// and its identity provider, so that code built on the client package can be
+// tested without network access.
+//
+// The fake keeps organizations, projects, networks, peerings, ACLs, managed
+// clusters, jobs and integrations in memory and moves them through the same
+// states as the real API, one step per read. Faults such as throttling,
+// server errors and defunct resources can be injected to exercise error
+// handling.
+package clienttest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/lestrrat-go/jwx/jwa"
+)
+
+const (
+	// DefaultOrganizationID is the organization the server starts with,
+	// unless Config.Organizations is set
+	DefaultOrganizationID = "test-organization"
+	// RefreshToken is accepted by the fake identity provider by default
+	RefreshToken = "test-refresh-token"
+)
+
+type Config struct {
+	// Organizations accessible to the caller. Defaults to a single
+	// organization with DefaultOrganizationID.
+	Organizations []client.Organization
+	// Refresh tokens accepted by the identity provider. Defaults to
+	// RefreshToken.
+	RefreshTokens []string
+	// Client secrets accepted for the client credentials grant, by client ID
+	ClientSecrets map[string]string
+	// OIDC tokens accepted as subject tokens by the token exchange grant.
+	// Any token is accepted when nil.
+	SubjectTokens []string
+	// Errors answering the polls of each device login, such as
+	// authorization_pending or slow_down, before the user approves it. An
+	// access_denied or expired_token error ends the login. Refresh tokens
+	// issued once approved are accepted like RefreshTokens.
+	DeviceLoginErrors []string
+	// Number of reads for which a resource remains in an intermediate state,
+	// such as provisioning, before reaching its target state. Reads are
+	// counted per resource. Zero makes every transition complete on the
+	// first read.
+	PendingReads int
+	// Drop jobs and integrations once their deletion completes, so that
+	// reading them answers 404, as the API may do, rather than reporting
+	// them deleted
+	RemoveDeleted bool
+	// Lifetime of issued access tokens. Defaults to an hour.
+	TokenLifetime time.Duration
+	// Claims added to every access token issued, such as those naming the
+	// organizations of the caller. They replace the usual claims of the same
+	// name, such as aud or iss.
+	TokenClaims map[string]interface{}
+	// Algorithm signing access tokens and the key ID in their header.
+	// Default to RS256 and the key published by the identity provider.
+	// Others make tokens the client must reject: HS algorithms sign with a
+	// shared secret, and jwa.NoSignature leaves tokens unsigned.
+	TokenAlgorithm jwa.SignatureAlgorithm
+	TokenKeyID     string
+}
+
+// Server is a running fake of the Event Store Cloud API. It serves both the
+// API and the identity provider from URL.
+type Server struct {
+	URL string
+
+	httpServer *httptest.Server
+	config     Config
+	issuer     *issuer
+
+	mu            sync.Mutex
+	organizations map[string]*client.Organization
+	projects      map[string]*client.Project
+	networks      map[string]*client.Network
+	peerings      map[string]*client.Peering
+	acls          map[string]*client.Acl
+	clusters      map[string]*client.ManagedCluster
+	jobs          map[string]*client.Job
+	integrations  map[string]*client.Integration
+	credentials   map[string]*client.GetManagedClusterInitialCredentialsResponse
+	deviceLogins  map[string]*deviceLogin
+	transitions   map[string]*transition
+	faults        []*Fault
+	requests      []Request
+}
+
+// transition completes a change of state once the resource has been read
+// pendingReads more times
+type transition struct {
+	pendingReads int
+	complete     func()
+}
+
+// Request records a call made against the server
+type Request struct {
+	Method    string
+	Path      string
+	RequestID string
+	UserAgent string
+	// Subject of the access token sent with the request, if the server
+	// issued it
+	Subject string
+	Time    time.Time
+}
+
+// NewServer starts a fake server. It must be closed once done with.
+func NewServer(config *Config) *Server {
+	s := &Server{
+		organizations: map[string]*client.Organization{},
+		projects:      map[string]*client.Project{},
+		networks:      map[string]*client.Network{},
+		peerings:      map[string]*client.Peering{},
+		acls:          map[string]*client.Acl{},
+		clusters:      map[string]*client.ManagedCluster{},
+		jobs:          map[string]*client.Job{},
+		integrations:  map[string]*client.Integration{},
+		credentials:   map[string]*client.GetManagedClusterInitialCredentialsResponse{},
+		deviceLogins:  map[string]*deviceLogin{},
+		transitions:   map[string]*transition{},
+	}
+	if config != nil {
+		s.config = *config
+	}
+	if len(s.config.Organizations) == 0 {
+		s.config.Organizations = []client.Organization{{
+			OrganizationID: DefaultOrganizationID,
+			Name:           "Test Organization",
+			Created:        timestamp(),
+		}}
+	}
+	if len(s.config.RefreshTokens) == 0 {
+		s.config.RefreshTokens = []string{RefreshToken}
+	}
+	if s.config.TokenLifetime <= 0 {
+		s.config.TokenLifetime = time.Hour
+	}
+	for i := range s.config.Organizations {
+		organization := s.config.Organizations[i]
+		s.organizations[organization.OrganizationID] = &organization
+	}
+
+	mux := http.NewServeMux()
+	s.registerIdentityProvider(mux)
+	s.registerResources(mux)
+	s.registerInfra(mux)
+	s.registerMesdb(mux)
+	s.registerOrchestrate(mux)
+	s.registerIntegrate(mux)
+
+	s.httpServer = httptest.NewServer(s.handle(mux))
+	s.URL = s.httpServer.URL
+	s.issuer = newIssuer(s.URL, &s.config)
+
+	return s
+}
+
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// ClientConfig returns a client configuration for the server, signing in
+// with RefreshToken and keeping tokens in memory. Retries are quick so that
+// injected faults don't slow tests down.
+func (s *Server) ClientConfig() *client.Config {
+	return &client.Config{
+		URL:                 s.URL,
+		IdentityProviderURL: s.URL,
+		RefreshToken:        s.config.RefreshTokens[0],
+		TokenStoreType:      client.TokenStoreMemory,
+		UserAgent:           "clienttest",
+		RetryWaitMin:        10 * time.Millisecond,
+		RetryWaitMax:        50 * time.Millisecond,
+	}
+}
+
+// NewClient returns a client for the server, as configured by ClientConfig
+func (s *Server) NewClient() (*client.Client, error) {
+	return client.New(s.ClientConfig())
+}
+
+// Requests returns the calls made against the server so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// SetPendingReads changes Config.PendingReads for transitions started from
+// now on
+func (s *Server) SetPendingReads(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config.PendingReads = n
+}
+
+// handle records requests, injects faults and checks access tokens before
+// passing requests on to the API
+func (s *Server) handle(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, bearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		subject, valid := s.issuer.subject(token)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			RequestID: r.Header.Get("X-Request-Id"),
+			UserAgent: r.Header.Get("User-Agent"),
+			Subject:   subject,
+			Time:      time.Now(),
+		})
+		fault := s.matchFault(r)
+		s.mu.Unlock()
+
+		if fault != nil {
+			if fault.Delay > 0 {
+				// The server only notices clients giving up once the body
+				// has been read
+				body, _ := io.ReadAll(r.Body)
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				select {
+				case <-time.After(fault.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if fault.StatusCode != 0 {
+				writeFault(w, fault)
+				return
+			}
+		}
+
+		if !strings.HasPrefix(r.URL.Path, "/oauth/") && !strings.HasPrefix(r.URL.Path, "/.well-known/") {
+			if !bearer || !valid {
+				writeProblem(w, http.StatusUnauthorized, "Unauthorized", "a valid access token is required", nil)
+				return
+			}
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// advance moves the resource with the given ID one read closer to the end of
+// its transition, if any. The caller must hold s.mu.
+func (s *Server) advance(id string) {
+	t, ok := s.transitions[id]
+	if !ok {
+		return
+	}
+	if t.pendingReads > 0 {
+		t.pendingReads--
+		return
+	}
+
+	delete(s.transitions, id)
+	t.complete()
+}
+
+// startTransition schedules complete to run once the resource with the given
+// ID has been read Config.PendingReads times. The caller must hold s.mu.
+func (s *Server) startTransition(id string, complete func()) {
+	s.transitions[id] = &transition{
+		pendingReads: s.config.PendingReads,
+		complete:     complete,
+	}
+}
+
+func newID() string {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+	b := make([]byte, 20)
+	_, _ = rand.Read(b)
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeProblem(w http.ResponseWriter, status int, title string, detail string, fields map[string]string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(client.ProblemDetails{
+		Title:  title,
+		Status: status,
+		Detail: detail,
+		Fields: fields,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, kind string, id string) {
+	writeProblem(w, http.StatusNotFound, "Not Found", fmt.Sprintf("%s %s not found", kind, id), nil)
+}
+
+// decode reads a JSON request body into v, answering with a 400 when it
+// cannot be parsed
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("invalid request body: %s", err), nil)
+		return false
+	}
+	return true
+}
+
+// validation collects errors for individual request fields
+type validation map[string]string
+
+func (v validation) require(field string, ok bool, message string) {
+	if !ok {
+		v[field] = message
+	}
+}
+
+// failed answers with the collected errors, if any
+func (v validation) failed(w http.ResponseWriter) bool {
+	if len(v) == 0 {
+		return false
+	}
+	writeProblem(w, http.StatusBadRequest, "Bad Request", "one or more fields are invalid", v)
+	return true
+}
@@ -0,0 +1,325 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+func newTestClient(t *testing.T, config *Config) (*Server, *client.Client) {
+	t.Helper()
+
+	server := NewServer(config)
+	t.Cleanup(server.Close)
+
+	c, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+	return server, c
+}
+
+func TestClusterLifecycle(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestClient(t, nil)
+
+	project, err := c.ProjectCreate(ctx, &client.CreateProjectRequest{
+		OrganizationID: DefaultOrganizationID,
+		Name:           "project",
+	})
+	if err != nil {
+		t.Fatalf("creating project: %s", err)
+	}
+
+	network, err := c.NetworkCreate(ctx, &client.CreateNetworkRequest{
+		OrganizationID:   DefaultOrganizationID,
+		ProjectID:        project.ProjectID,
+		ResourceProvider: "aws",
+		CidrBlock:        "172.21.0.0/16",
+		Name:             "network",
+		Region:           "eu-west-1",
+	})
+	if err != nil {
+		t.Fatalf("creating network: %s", err)
+	}
+	if err := c.NetworkWaitForState(ctx, &client.WaitForNetworkStateRequest{
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		NetworkID:      network.NetworkID,
+		State:          "available",
+	}); err != nil {
+		t.Fatalf("waiting for network: %s", err)
+	}
+
+	peering, err := c.PeeringCreate(ctx, &client.CreatePeeringRequest{
+		OrganizationID:        DefaultOrganizationID,
+		ProjectID:             project.ProjectID,
+		NetworkId:             network.NetworkID,
+		Name:                  "peering",
+		PeerAccountIdentifier: "123456789012",
+		PeerNetworkIdentifier: "vpc-12345678",
+		PeerNetworkRegion:     "eu-west-1",
+		Routes:                []string{"10.0.0.0/16"},
+	})
+	if err != nil {
+		t.Fatalf("creating peering: %s", err)
+	}
+	initiated, err := c.PeeringWaitForState(ctx, &client.WaitForPeeringStateRequest{
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		PeeringID:      peering.PeeringID,
+		State:          "initiated",
+	})
+	if err != nil {
+		t.Fatalf("waiting for peering: %s", err)
+	}
+	if initiated.ProviderPeeringMetadata["peeringLinkId"] == "" {
+		t.Errorf("expected the peering link ID once initiated, got %v", initiated.ProviderPeeringMetadata)
+	}
+
+	cluster, err := c.ManagedClusterCreate(ctx, &client.CreateManagedClusterRequest{
+		OrganizationID:  DefaultOrganizationID,
+		ProjectID:       project.ProjectID,
+		NetworkId:       network.NetworkID,
+		Name:            "cluster",
+		Topology:        "single-node",
+		InstanceType:    "F1",
+		DiskSizeGB:      10,
+		DiskType:        "GP3",
+		DiskIops:        3000,
+		DiskThroughput:  125,
+		ServerVersion:   "24.10",
+		ProjectionLevel: "off",
+	})
+	if err != nil {
+		t.Fatalf("creating cluster: %s", err)
+	}
+	waitForCluster := func(state string) {
+		t.Helper()
+		if err := c.ManagedClusterWaitForState(ctx, &client.WaitForManagedClusterStateRequest{
+			OrganizationID: DefaultOrganizationID,
+			ProjectID:      project.ProjectID,
+			ClusterID:      cluster.ClusterID,
+			State:          state,
+		}); err != nil {
+			t.Fatalf("waiting for cluster to be %s: %s", state, err)
+		}
+	}
+	waitForCluster("available")
+
+	if err := c.ManagedClusterResize(ctx, &client.ManagedClusterResizeRequest{
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		ClusterID:      cluster.ClusterID,
+		TargetSize:     "C4",
+	}); err != nil {
+		t.Fatalf("resizing cluster: %s", err)
+	}
+	waitForCluster("available")
+
+	got, err := c.ManagedClusterGet(ctx, &client.GetManagedClusterRequest{
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		ClusterID:      cluster.ClusterID,
+	})
+	if err != nil {
+		t.Fatalf("getting cluster: %s", err)
+	}
+	if got.ManagedCluster.InstanceType != "c4" || got.ManagedCluster.Provider != "aws" {
+		t.Errorf("unexpected cluster after resize: %+v", got.ManagedCluster)
+	}
+
+	if err := c.NetworkDelete(ctx, &client.DeleteNetworkRequest{
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		NetworkID:      network.NetworkID,
+	}); !errors.Is(err, client.ErrConflict) {
+		t.Errorf("expected a conflict deleting a network in use, got %v", err)
+	}
+
+	if err := c.ManagedClusterDelete(ctx, &client.DeleteManagedClusterRequest{
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		ClusterID:      cluster.ClusterID,
+	}); err != nil {
+		t.Fatalf("deleting cluster: %s", err)
+	}
+	waitForCluster(client.StateDeleted)
+}
+
+func TestPendingReads(t *testing.T) {
+	ctx := context.Background()
+	server, c := newTestClient(t, &Config{PendingReads: 2})
+
+	project, err := c.ProjectCreate(ctx, &client.CreateProjectRequest{OrganizationID: DefaultOrganizationID, Name: "project"})
+	if err != nil {
+		t.Fatalf("creating project: %s", err)
+	}
+	network, err := c.NetworkCreate(ctx, &client.CreateNetworkRequest{
+		OrganizationID:   DefaultOrganizationID,
+		ProjectID:        project.ProjectID,
+		ResourceProvider: "gcp",
+		CidrBlock:        "172.21.0.0/16",
+		Name:             "network",
+		Region:           "europe-west4",
+	})
+	if err != nil {
+		t.Fatalf("creating network: %s", err)
+	}
+
+	request := &client.GetNetworkRequest{OrganizationID: DefaultOrganizationID, ProjectID: project.ProjectID, NetworkID: network.NetworkID}
+	for i, expected := range []string{"provisioning", "provisioning", "available", "defunct"} {
+		if expected == "defunct" {
+			if err := server.SetStatus(network.NetworkID, expected); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		resp, err := c.NetworkGet(ctx, request)
+		if err != nil {
+			t.Fatalf("getting network: %s", err)
+		}
+		if resp.Network.Status != expected {
+			t.Errorf("read %d: expected %s, got %s", i+1, expected, resp.Network.Status)
+		}
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	_, c := newTestClient(t, nil)
+
+	_, err := c.ProjectCreate(context.Background(), &client.CreateProjectRequest{OrganizationID: DefaultOrganizationID})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a bad request, got %v", err)
+	}
+	if apiErr.ProblemDetails.Fields["name"] == "" {
+		t.Errorf("expected an error for the name field, got %v", apiErr.ProblemDetails.Fields)
+	}
+}
+
+func TestInjectedFaultsAreRetried(t *testing.T) {
+	server, c := newTestClient(t, nil)
+
+	server.InjectFault(Fault{PathPrefix: "/resources/", StatusCode: http.StatusTooManyRequests, Times: 2})
+	server.InjectFault(Fault{PathPrefix: "/resources/", StatusCode: http.StatusBadGateway, Times: 1})
+
+	if _, err := c.OrganizationList(context.Background()); err != nil {
+		t.Fatalf("expected the faults to be retried, got %s", err)
+	}
+
+	calls := 0
+	for _, request := range server.Requests() {
+		if request.Path == "/resources/v1/organizations" {
+			calls++
+			if request.RequestID == "" || request.UserAgent != "clienttest" {
+				t.Errorf("expected correlation headers, got %+v", request)
+			}
+		}
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	server, c := newTestClient(t, nil)
+
+	// Far longer than the client's maximum wait
+	server.InjectFault(Fault{PathPrefix: "/resources/", StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour, Times: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := c.OrganizationList(ctx); err != nil {
+		t.Fatalf("expected the fault to be retried without waiting for an hour, got %s", err)
+	}
+}
+
+func TestRetryAfterPastDeadlineFails(t *testing.T) {
+	server, _ := newTestClient(t, nil)
+	config := server.ClientConfig()
+	config.RetryWaitMax = time.Minute
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.InjectFault(Fault{PathPrefix: "/resources/", StatusCode: http.StatusTooManyRequests, RetryAfter: 30 * time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The throttling is reported rather than waited out until the deadline
+	start := time.Now()
+	_, err = c.OrganizationList(ctx)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the throttling error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("expected the request to fail without waiting, took %s", elapsed)
+	}
+}
+
+func TestPersistentFaultFails(t *testing.T) {
+	server, c := newTestClient(t, nil)
+
+	server.InjectFault(Fault{PathPrefix: "/resources/", StatusCode: http.StatusInternalServerError})
+
+	_, err := c.OrganizationList(context.Background())
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected an internal server error, got %v", err)
+	}
+}
+
+func TestUnknownRefreshToken(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+
+	config := server.ClientConfig()
+	config.RefreshToken = "unknown"
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.OrganizationList(context.Background()); err == nil {
+		t.Fatal("expected an error with an unknown refresh token")
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	server := NewServer(&Config{ClientSecrets: map[string]string{"machine": "secret"}})
+	defer server.Close()
+
+	config := server.ClientConfig()
+	config.ClientID = "machine"
+	config.ClientSecret = "secret"
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second call validates the cached token against the published keys
+	for i := 0; i < 2; i++ {
+		if _, err := c.OrganizationList(context.Background()); err != nil {
+			t.Fatalf("call %d: %s", i+1, err)
+		}
+	}
+
+	tokenRequests := 0
+	for _, request := range server.Requests() {
+		if request.Path == "/oauth/token" {
+			tokenRequests++
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("expected the cached token to be reused, got %d token requests", tokenRequests)
+	}
+}
@@ -2,37 +2,11 @@ package client
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"path"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
 )
 
-type ManagedCluster struct {
-	OrganizationID   string `json:"organizationId"`
-	ProjectID        string `json:"projectId"`
-	NetworkID        string `json:"networkId"`
-	ClusterID        string `json:"id"`
-	Name             string `json:"description"`
-	Provider         string `json:"provider"`
-	Region           string `json:"region"`
-	Topology         string `json:"topology"`
-	InstanceType     string `json:"instanceType"`
-	DiskSizeGB       int32  `json:"diskSizeGb"`
-	DiskType         string `json:"diskType"`
-	DiskIops         int32  `json:"diskIops"`
-	DiskThroughput   int32  `json:"diskThroughput"`
-	ServerVersion    string `json:"serverVersion"`
-	ServerVersionTag string `json:"serverVersionTag"`
-	ProjectionLevel  string `json:"projectionLevel"`
-	Status           string `json:"status"`
-	Created          string `json:"created"`
-	Protected        bool   `json:"protected"`
-	AclId            string `json:"aclId"`
-	PublicAccess     bool   `json:"publicAccess"`
-}
+type ManagedCluster = escapi.ManagedCluster
 
 type GetManagedClusterRequest struct {
 	OrganizationID string
@@ -40,49 +14,11 @@ type GetManagedClusterRequest struct {
 	ClusterID      string
 }
 
-type GetManagedClusterResponse struct {
-	ManagedCluster ManagedCluster `json:"cluster"`
-}
+type GetManagedClusterResponse = escapi.GetManagedClusterResponse
 
 func (c *Client) ManagedClusterGet(
 	ctx context.Context,
 	req *GetManagedClusterRequest,
-) (*GetManagedClusterResponse, diag.Diagnostics) {
-	requestURL := *c.apiURL
-	requestURL.Path = path.Join(
-		"mesdb",
-		"v1",
-		"organizations",
-		req.OrganizationID,
-		"projects",
-		req.ProjectID,
-		"clusters",
-		req.ClusterID,
-	)
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error constructing request: %w", err))
-	}
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error sending request: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, translateStatusCode(resp.StatusCode, "getting managed cluster", resp.Body)
-	}
-
-	decoder := json.NewDecoder(resp.Body)
-	result := GetManagedClusterResponse{}
-	if err := decoder.Decode(&result); err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error parsing response: %w", err))
-	}
-
-	return &result, nil
+) (*GetManagedClusterResponse, error) {
+	return c.api.GetManagedCluster(ctx, req.OrganizationID, req.ProjectID, req.ClusterID)
 }
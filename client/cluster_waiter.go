@@ -2,9 +2,8 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"time"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type WaitForManagedClusterStateRequest struct {
@@ -12,40 +11,34 @@ type WaitForManagedClusterStateRequest struct {
 	ProjectID      string
 	ClusterID      string
 	State          string
+	// Maximum time to wait for State, unbounded (apart from ctx) when zero
+	Timeout time.Duration
 }
 
 func (c *Client) ManagedClusterWaitForState(
 	ctx context.Context,
 	req *WaitForManagedClusterStateRequest,
-) diag.Diagnostics {
-	start := time.Now()
-
+) error {
 	getRequest := &GetManagedClusterRequest{
 		OrganizationID: req.OrganizationID,
 		ProjectID:      req.ProjectID,
 		ClusterID:      req.ClusterID,
 	}
 
-	for {
+	description := fmt.Sprintf("cluster %s", req.ClusterID)
+
+	return Poll(ctx, &PollConfig{
+		Description: description,
+		Target:      []string{req.State},
+		Failures:    []PollFailure{{State: StateDefunct, GracePeriod: defunctGracePeriod}},
+		Timeout:     req.Timeout,
+		OnProgress:  logPollProgress(ctx, description),
+	}, func(ctx context.Context) (string, error) {
 		resp, err := c.ManagedClusterGet(ctx, getRequest)
 		if err != nil {
-			return err
-		}
-
-		if resp.ManagedCluster.Status == "defunct" {
-			// Resources in a `defunct` state may not update their status right
-			// away when being destroyed, so wait a bit before failing the operation.
-			elapsed := time.Since(start)
-			if elapsed.Seconds() > 30.0 {
-				return diag.Errorf("Cluster entered a defunct state!")
-			}
-		}
-
-		if resp.ManagedCluster.Status != req.State {
-			time.Sleep(5 * time.Second)
-			continue
+			return "", err
 		}
 
-		return nil
-	}
+		return resp.ManagedCluster.Status, nil
+	})
 }
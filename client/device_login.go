@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// In seconds, like the intervals sent by the identity provider
+	defaultDeviceLoginInterval = 5
+	// RFC 8628 asks clients to back off by 5 seconds on every slow_down
+	deviceLoginSlowDown = 5
+)
+
+// deviceLoginSecond is the unit of the intervals and expiry of the device
+// flow, which tests shorten
+var deviceLoginSecond = time.Second
+
+// DeviceAuthorization is the response of the identity provider to a device
+// authorization request, telling the user where to approve the login.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// Login runs the OAuth 2.0 device authorization flow (RFC 8628). prompt is
+// called once the user code is known and should tell the user where to enter
+// it. Login then waits until the user approves or denies the request, and
+// stores the resulting tokens in the token store, where the provider picks up
+// the refresh token when none is configured.
+func (c *Client) Login(ctx context.Context, prompt func(*DeviceAuthorization)) error {
+	authorization, err := c.requestDeviceAuthorization(ctx)
+	if err != nil {
+		return err
+	}
+
+	prompt(authorization)
+
+	if authorization.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(authorization.ExpiresIn)*deviceLoginSecond)
+		defer cancel()
+	}
+
+	interval := authorization.Interval
+	if interval <= 0 {
+		interval = defaultDeviceLoginInterval
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", grantTypeDeviceCode)
+	form.Set("device_code", authorization.DeviceCode)
+	form.Set("client_id", c.clientID)
+
+	for {
+		timer := time.NewTimer(time.Duration(interval) * deviceLoginSecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return errors.New("the login request expired before it was approved")
+			}
+			return fmt.Errorf("login cancelled: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		result, err := c.postTokenForm(ctx, form)
+		if err != nil {
+			var oauthErr *oauthError
+			if errors.As(err, &oauthErr) {
+				switch oauthErr.Code {
+				case "authorization_pending":
+					continue
+				case "slow_down":
+					interval += deviceLoginSlowDown
+					continue
+				case "expired_token":
+					return errors.New("the login request expired before it was approved")
+				case "access_denied":
+					return errors.New("the login request was denied")
+				}
+			}
+			return err
+		}
+
+		if result.RefreshToken == "" {
+			return errors.New("the identity provider did not issue a refresh token")
+		}
+		result.ClientID = c.clientID
+
+		unlock, err := c.tokenStore.lock(c.audience)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		if err := c.tokenStore.put(c.audience, *result); err != nil {
+			return fmt.Errorf("error writing token to store: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func (c *Client) requestDeviceAuthorization(ctx context.Context) (*DeviceAuthorization, error) {
+	idpURL := *c.idpURL
+	idpURL.Path = "/oauth/device/code"
+
+	form := url.Values{}
+	form.Set("client_id", c.clientID)
+	form.Set("audience", apiAudience)
+	form.Set("scope", "openid offline_access")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, idpURL.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error constructing request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting device authorization: %w", err)
+	}
+	defer closeIgnoreError(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error %d requesting device authorization: %w", resp.StatusCode, newOAuthErrorFromReader(resp.Body))
+	}
+
+	result := DeviceAuthorization{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error parsing IDP response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,165 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// The fake identity provider asks to poll every second, which tests shorten
+// to this
+const deviceLoginSecond = 10 * time.Millisecond
+
+// newLoginClient returns a client for server without credentials, keeping
+// its tokens in tokenStore as the login command does
+func newLoginClient(t *testing.T, server *clienttest.Server, tokenStore string) *client.Client {
+	t.Helper()
+
+	config := server.ClientConfig()
+	config.RefreshToken = ""
+	config.TokenStoreType = client.TokenStoreFile
+	config.TokenStore = tokenStore
+
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+	return c
+}
+
+// devicePolls returns the requests polling for the device login's approval
+func devicePolls(server *clienttest.Server) []clienttest.Request {
+	var polls []clienttest.Request
+	afterAuthorization := false
+	for _, request := range server.Requests() {
+		switch {
+		case request.Path == "/oauth/device/code":
+			afterAuthorization = true
+		case request.Path == "/oauth/token" && afterAuthorization:
+			polls = append(polls, request)
+		}
+	}
+	return polls
+}
+
+func TestLogin(t *testing.T) {
+	client.SetDeviceLoginSecond(t, deviceLoginSecond)
+	ctx := context.Background()
+	server := clienttest.NewServer(&clienttest.Config{
+		DeviceLoginErrors: []string{"authorization_pending", "authorization_pending"},
+	})
+	t.Cleanup(server.Close)
+	tokenStore := t.TempDir()
+	c := newLoginClient(t, server, tokenStore)
+
+	var authorization *client.DeviceAuthorization
+	err := c.Login(ctx, func(a *client.DeviceAuthorization) { authorization = a })
+	if err != nil {
+		t.Fatalf("logging in: %s", err)
+	}
+
+	if authorization == nil || authorization.UserCode == "" || !strings.HasPrefix(authorization.VerificationURI, server.URL) {
+		t.Errorf("expected to be prompted with a user code and URL, got %+v", authorization)
+	}
+	if polls := len(devicePolls(server)); polls != 3 {
+		t.Errorf("expected polls until the login was approved, got %d", polls)
+	}
+
+	// The refresh token is kept for the provider, which has none configured
+	info, err := newLoginClient(t, server, tokenStore).TokenInspect(ctx)
+	if err != nil {
+		t.Fatalf("inspecting token: %s", err)
+	}
+	if !info.HasRefreshToken || info.Subject != "user|clienttest" {
+		t.Errorf("expected the login's tokens to be stored, got %+v", info)
+	}
+	if err := newLoginClient(t, server, tokenStore).TokenRefresh(ctx, true); err != nil {
+		t.Errorf("refreshing with the stored refresh token: %s", err)
+	}
+}
+
+func TestLoginSlowDown(t *testing.T) {
+	client.SetDeviceLoginSecond(t, deviceLoginSecond)
+	server := clienttest.NewServer(&clienttest.Config{
+		DeviceLoginErrors: []string{"authorization_pending", "slow_down"},
+	})
+	t.Cleanup(server.Close)
+
+	if err := newLoginClient(t, server, t.TempDir()).Login(context.Background(), func(*client.DeviceAuthorization) {}); err != nil {
+		t.Fatalf("logging in: %s", err)
+	}
+
+	polls := devicePolls(server)
+	if len(polls) != 3 {
+		t.Fatalf("expected 3 polls, got %d", len(polls))
+	}
+	// The interval of a second grows by five on slow_down
+	if interval := polls[2].Time.Sub(polls[1].Time); interval < 6*deviceLoginSecond {
+		t.Errorf("expected the interval to grow after slow_down, got %s", interval)
+	}
+}
+
+func TestLoginFailures(t *testing.T) {
+	client.SetDeviceLoginSecond(t, deviceLoginSecond)
+
+	tests := []struct {
+		name    string
+		errors  []string
+		message string
+	}{
+		{
+			name:    "expired",
+			errors:  []string{"authorization_pending", "expired_token"},
+			message: "the login request expired before it was approved",
+		},
+		{
+			name:    "denied",
+			errors:  []string{"access_denied"},
+			message: "the login request was denied",
+		},
+		{
+			name:    "other error",
+			errors:  []string{"invalid_client"},
+			message: "invalid_client",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := clienttest.NewServer(&clienttest.Config{DeviceLoginErrors: tt.errors})
+			t.Cleanup(server.Close)
+			tokenStore := t.TempDir()
+
+			err := newLoginClient(t, server, tokenStore).Login(context.Background(), func(*client.DeviceAuthorization) {})
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected an error containing %q, got %v", tt.message, err)
+			}
+			if polls := len(devicePolls(server)); polls != len(tt.errors) {
+				t.Errorf("expected polling to stop at the error, got %d polls", polls)
+			}
+			if _, err := newLoginClient(t, server, tokenStore).TokenInspect(context.Background()); err == nil {
+				t.Error("expected no token to be stored")
+			}
+		})
+	}
+}
+
+func TestLoginCancellation(t *testing.T) {
+	client.SetDeviceLoginSecond(t, deviceLoginSecond)
+	server := clienttest.NewServer(&clienttest.Config{DeviceLoginErrors: []string{"authorization_pending"}})
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := newLoginClient(t, server, t.TempDir()).Login(ctx, func(*client.DeviceAuthorization) { cancel() })
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "login cancelled") {
+		t.Errorf("expected the login to be cancelled, got %v", err)
+	}
+	if polls := len(devicePolls(server)); polls != 0 {
+		t.Errorf("expected no polls once cancelled, got %d", polls)
+	}
+}
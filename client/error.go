@@ -2,12 +2,26 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"html"
+	"net/http"
+	"regexp"
 	"sort"
+	"strings"
 )
 
-type problemDetails struct {
+// Sentinel errors matched by APIError, so callers can use errors.Is without
+// inspecting status codes
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// ProblemDetails is the RFC 7807 error body returned by the Event Store Cloud API
+type ProblemDetails struct {
 	Type     string            `json:"type"`
 	Title    string            `json:"title"`
 	Status   int               `json:"status"`
@@ -16,18 +30,24 @@ type problemDetails struct {
 	Fields   map[string]string `json:"fields,omitempty"`
 }
 
-func (problemDetails *problemDetails) Error() string {
+// Message describes the problem without the errors of individual fields
+func (problemDetails *ProblemDetails) Message() string {
 	if problemDetails.Title == "" && problemDetails.Detail == "" {
 		return fmt.Sprintf("Status %d", problemDetails.Status)
 	}
 
-	err := ""
+	message := ""
 	if problemDetails.Title != "" {
-		err += problemDetails.Title
+		message += problemDetails.Title
 	}
 	if problemDetails.Detail != "" {
-		err += fmt.Sprintf(": %s", problemDetails.Detail)
+		message += fmt.Sprintf(": %s", problemDetails.Detail)
 	}
+	return message
+}
+
+func (problemDetails *ProblemDetails) Error() string {
+	err := problemDetails.Message()
 
 	if len(problemDetails.Fields) > 0 {
 		keys := make([]string, 0)
@@ -44,13 +64,78 @@ func (problemDetails *problemDetails) Error() string {
 	return err
 }
 
-func newProblemDetailsFromReader(reader io.Reader) (*problemDetails, error) {
-	var details problemDetails
-	decoder := json.NewDecoder(reader)
+// newProblemDetails parses an error response. Bodies which aren't problem
+// details, such as the HTML error pages of load balancers, are summarized
+// instead.
+func newProblemDetails(status int, body []byte) *ProblemDetails {
+	var details ProblemDetails
+	if err := json.Unmarshal(body, &details); err == nil && (details.Title != "" || details.Detail != "" || len(details.Fields) > 0) {
+		if details.Status == 0 {
+			details.Status = status
+		}
+		return &details
+	}
+
+	return &ProblemDetails{
+		Status: status,
+		Title:  http.StatusText(status),
+		Detail: summarizeBody(body),
+	}
+}
+
+const maxSummaryLength = 200
+
+var (
+	htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// summarizeBody turns an unexpected response body into a single line fit
+// for an error message
+func summarizeBody(body []byte) string {
+	text := string(body)
+	if match := htmlTitlePattern.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	} else {
+		text = htmlTagPattern.ReplaceAllString(text, " ")
+	}
+	text = html.UnescapeString(strings.Join(strings.Fields(text), " "))
 
-	if err := decoder.Decode(&details); err != nil {
-		return nil, err
+	if runes := []rune(text); len(runes) > maxSummaryLength {
+		text = string(runes[:maxSummaryLength]) + "..."
 	}
+	return text
+}
+
+// APIError is returned for every non-successful response from the Event Store
+// Cloud API
+type APIError struct {
+	StatusCode int
+	// Describes the failed operation, e.g. "getting network"
+	Activity       string
+	ProblemDetails *ProblemDetails
+}
 
-	return &details, nil
+func (e *APIError) Error() string {
+	return fmt.Sprintf("error %s (status %d): %s", e.Activity, e.StatusCode, e.ProblemDetails.Error())
+}
+
+// Message is the error without the errors of individual fields, for callers
+// reporting those separately
+func (e *APIError) Message() string {
+	return fmt.Sprintf("error %s (status %d): %s", e.Activity, e.StatusCode, e.ProblemDetails.Message())
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
 }
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewProblemDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		title  string
+		detail string
+		fields map[string]string
+	}{
+		{
+			name:   "problem details",
+			status: http.StatusBadRequest,
+			body:   `{"title":"Bad Request","detail":"invalid network","fields":{"cidrBlock":"must be private"}}`,
+			title:  "Bad Request",
+			detail: "invalid network",
+			fields: map[string]string{"cidrBlock": "must be private"},
+		},
+		{
+			name:   "HTML error page with a title",
+			status: http.StatusBadGateway,
+			body:   "<html>\n<head><title>502 Bad Gateway</title></head>\n<body><center><h1>502 Bad Gateway</h1></center><hr><center>nginx</center></body>\n</html>",
+			title:  "Bad Gateway",
+			detail: "502 Bad Gateway",
+		},
+		{
+			name:   "HTML error page without a title",
+			status: http.StatusServiceUnavailable,
+			body:   "<html><body><h1>Service   Unavailable</h1>\n<p>Back &amp; running soon</p></body></html>",
+			title:  "Service Unavailable",
+			detail: "Service Unavailable Back & running soon",
+		},
+		{
+			name:   "plain text",
+			status: http.StatusInternalServerError,
+			body:   "upstream connect error\n",
+			title:  "Internal Server Error",
+			detail: "upstream connect error",
+		},
+		{
+			name:   "JSON which isn't problem details",
+			status: http.StatusForbidden,
+			body:   `{"message":"Forbidden"}`,
+			title:  "Forbidden",
+			detail: `{"message":"Forbidden"}`,
+		},
+		{
+			name:   "empty body",
+			status: http.StatusNotFound,
+			title:  "Not Found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details := newProblemDetails(tt.status, []byte(tt.body))
+
+			if details.Status != tt.status || details.Title != tt.title || details.Detail != tt.detail {
+				t.Errorf("expected %d %q %q, got %d %q %q", tt.status, tt.title, tt.detail, details.Status, details.Title, details.Detail)
+			}
+			if len(details.Fields) != len(tt.fields) {
+				t.Errorf("expected fields %v, got %v", tt.fields, details.Fields)
+			}
+			for key, value := range tt.fields {
+				if details.Fields[key] != value {
+					t.Errorf("expected fields %v, got %v", tt.fields, details.Fields)
+				}
+			}
+		})
+	}
+
+	t.Run("long body", func(t *testing.T) {
+		details := newProblemDetails(http.StatusBadGateway, []byte(strings.Repeat("é", 500)))
+
+		if details.Detail != strings.Repeat("é", maxSummaryLength)+"..." {
+			t.Errorf("expected the body to be truncated to %d characters, got %q", maxSummaryLength, details.Detail)
+		}
+	})
+}
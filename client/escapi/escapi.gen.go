@@ -0,0 +1,928 @@
+// Code generated by apigen from openapi.json. DO NOT EDIT.
+
+package escapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// An organization, which owns projects
+type Organization struct {
+	OrganizationID string `json:"id"`
+	Name           string `json:"name"`
+	Created        string `json:"created"`
+}
+
+type ListOrganizationsResponse struct {
+	Organizations []Organization `json:"organizations"`
+}
+
+// A project, which groups the resources of an environment or application
+type Project struct {
+	ProjectID      string `json:"id"`
+	OrganizationID string `json:"organizationId"`
+	Name           string `json:"name"`
+	Created        string `json:"created"`
+}
+
+type ListProjectsResponse struct {
+	Projects []Project `json:"projects"`
+}
+
+type GetProjectResponse struct {
+	Project Project `json:"project"`
+}
+
+type CreateProjectRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateProjectResponse struct {
+	ProjectID string `json:"id"`
+}
+
+type UpdateProjectRequest struct {
+	Name string `json:"name"`
+}
+
+// A network in a cloud provider region, in which clusters run
+type Network struct {
+	NetworkID    string `json:"id"`
+	ProjectID    string `json:"projectId"`
+	Provider     string `json:"provider"`
+	Region       string `json:"region"`
+	CIDRBlock    string `json:"cidrBlock,omitempty"`
+	Name         string `json:"description"`
+	Status       string `json:"status"`
+	PublicAccess bool   `json:"publicAccess"`
+}
+
+type ListNetworksResponse struct {
+	Networks []Network `json:"networks"`
+}
+
+type GetNetworkResponse struct {
+	Network Network `json:"network"`
+}
+
+type CreateNetworkRequest struct {
+	Provider     string `json:"provider"`
+	CidrBlock    string `json:"cidrBlock,omitempty"`
+	Description  string `json:"description"`
+	PublicAccess bool   `json:"publicAccess,omitempty"`
+	Region       string `json:"region"`
+}
+
+type CreateNetworkResponse struct {
+	NetworkID string `json:"id"`
+}
+
+type UpdateNetworkRequest struct {
+	Description string `json:"description"`
+}
+
+// A peering link between a network and one of the customer's own
+type Peering struct {
+	ProjectID             string `json:"projectId"`
+	PeeringID             string `json:"id"`
+	NetworkID             string `json:"networkId"`
+	Provider              string `json:"provider"`
+	Name                  string `json:"description"`
+	PeerAccountIdentifier string `json:"peerAccountId"`
+	PeerNetworkIdentifier string `json:"peerNetworkId"`
+	PeerNetworkRegion     string `json:"peerNetworkRegion"`
+	// Provider specific details needed to complete the peering on the customer's
+	// side, such as the AWS peering link ID
+	ProviderPeeringMetadata map[string]string `json:"providerPeeringMetadata"`
+	Routes                  []string          `json:"routes"`
+	Status                  string            `json:"status"`
+	Created                 string            `json:"created,omitempty"`
+}
+
+type ListPeeringsResponse struct {
+	Peerings []Peering `json:"peerings"`
+}
+
+type GetPeeringResponse struct {
+	Peering Peering `json:"peering"`
+}
+
+type CreatePeeringRequest struct {
+	NetworkId         string   `json:"networkId"`
+	Description       string   `json:"description"`
+	PeerAccountId     string   `json:"peerAccountId"`
+	PeerNetworkId     string   `json:"peerNetworkId"`
+	PeerNetworkRegion string   `json:"peerNetworkRegion"`
+	Routes            []string `json:"routes"`
+}
+
+type CreatePeeringResponse struct {
+	PeeringID string `json:"id"`
+}
+
+type UpdatePeeringRequest struct {
+	Description string `json:"description"`
+}
+
+// An address range allowed by an ACL
+type AclCidrBlock struct {
+	Address string `json:"address"`
+	Comment string `json:"comment"`
+}
+
+// An access control list, restricting which addresses can reach a public
+// cluster
+type Acl struct {
+	AclID          string         `json:"id,omitempty"`
+	OrganizationID string         `json:"organizationId"`
+	ProjectID      string         `json:"projectId"`
+	CidrBlocks     []AclCidrBlock `json:"cidrBlocks"`
+	Created        string         `json:"created"`
+	Name           string         `json:"description"`
+	Status         string         `json:"status"`
+	Updated        string         `json:"updated"`
+}
+
+type ListAclsResponse struct {
+	Acls []Acl `json:"acls"`
+}
+
+type GetAclResponse struct {
+	Acl Acl `json:"acl"`
+}
+
+type CreateAclRequest struct {
+	Description string         `json:"description"`
+	CidrBlocks  []AclCidrBlock `json:"cidrBlocks"`
+}
+
+type CreateAclResponse struct {
+	AclID string `json:"id"`
+}
+
+type UpdateAclRequest struct {
+	CidrBlocks  []AclCidrBlock `json:"cidrBlocks,omitempty"`
+	Description string         `json:"description,omitempty"`
+}
+
+// A managed EventStoreDB cluster
+type ManagedCluster struct {
+	OrganizationID   string `json:"organizationId"`
+	ProjectID        string `json:"projectId"`
+	NetworkID        string `json:"networkId"`
+	ClusterID        string `json:"id"`
+	Name             string `json:"description"`
+	Provider         string `json:"provider"`
+	Region           string `json:"region"`
+	Topology         string `json:"topology"`
+	InstanceType     string `json:"instanceType"`
+	DiskSizeGB       int32  `json:"diskSizeGb"`
+	DiskType         string `json:"diskType"`
+	DiskIops         int32  `json:"diskIops"`
+	DiskThroughput   int32  `json:"diskThroughput"`
+	ServerVersion    string `json:"serverVersion"`
+	ServerVersionTag string `json:"serverVersionTag"`
+	ProjectionLevel  string `json:"projectionLevel"`
+	Status           string `json:"status"`
+	Created          string `json:"created"`
+	Protected        bool   `json:"protected"`
+	AclId            string `json:"aclId"`
+	PublicAccess     bool   `json:"publicAccess"`
+}
+
+type ListManagedClustersResponse struct {
+	ManagedClusters []ManagedCluster `json:"clusters"`
+}
+
+type GetManagedClusterResponse struct {
+	ManagedCluster ManagedCluster `json:"cluster"`
+}
+
+type CreateManagedClusterRequest struct {
+	NetworkId                     string `json:"networkId"`
+	Description                   string `json:"description"`
+	Topology                      string `json:"topology"`
+	InstanceType                  string `json:"instanceType"`
+	DiskSizeGB                    int32  `json:"diskSizeGb"`
+	DiskType                      string `json:"diskType"`
+	DiskIops                      int32  `json:"diskIops"`
+	DiskThroughput                int32  `json:"diskThroughput"`
+	ServerVersion                 string `json:"serverVersion"`
+	ProjectionLevel               string `json:"projectionLevel"`
+	CloudIntegratedAuthentication bool   `json:"cloudIntegratedAuthentication"`
+	Protected                     bool   `json:"protected"`
+	PublicAccess                  bool   `json:"publicAccess"`
+	AclId                         string `json:"aclId"`
+}
+
+type CreateManagedClusterResponse struct {
+	ClusterID string `json:"id"`
+}
+
+type UpdateManagedClusterRequest struct {
+	Description string `json:"description"`
+	Protected   bool   `json:"protected"`
+}
+
+type ResizeManagedClusterRequest struct {
+	// Instance type to move the cluster to
+	TargetSize string `json:"targetSize"`
+}
+
+type UpgradeManagedClusterRequest struct {
+	// Server version tag to move the cluster to
+	TargetTag string `json:"targetTag"`
+}
+
+type ExpandManagedClusterDiskRequest struct {
+	ClusterId      string `json:"clusterId"`
+	DiskIops       int32  `json:"diskIops,omitempty"`
+	DiskSizeGB     int32  `json:"diskSizeGb"`
+	DiskThroughput int32  `json:"diskThroughput,omitempty"`
+	DiskType       string `json:"diskType"`
+}
+
+type GetManagedClusterInitialCredentialsResponse struct {
+	AdminPassword string `json:"adminPassword"`
+	OpsPassword   string `json:"opsPassword"`
+	GeneratedAt   string `json:"generatedAt"`
+	ClusterID     string `json:"clusterId"`
+}
+
+// A job run on a schedule, such as taking backups
+type Job struct {
+	Data           map[string]interface{} `json:"data"`
+	Description    string                 `json:"description"`
+	Id             string                 `json:"id"`
+	OrganizationId string                 `json:"organizationId"`
+	ProjectId      string                 `json:"projectId"`
+	// Cron expression
+	Schedule string `json:"schedule"`
+	Status   string `json:"status"`
+	Type     string `json:"type"`
+}
+
+type ListJobsResponse struct {
+	Jobs []Job `json:"jobs"`
+}
+
+type GetJobResponse struct {
+	Job Job `json:"job"`
+}
+
+type CreateJobRequest struct {
+	Data        map[string]interface{} `json:"data"`
+	Description string                 `json:"description"`
+	Schedule    string                 `json:"schedule"`
+	Type        string                 `json:"type"`
+}
+
+type CreateJobResponse struct {
+	Id string `json:"id"`
+}
+
+type IntegrationStatus string
+
+// Values of IntegrationStatus
+const (
+	ACTIVE  IntegrationStatus = "active"
+	DELETED IntegrationStatus = "deleted"
+)
+
+// An integration, which sends notifications or metrics to a third party
+type Integration struct {
+	Created        time.Time              `json:"created"`
+	Data           map[string]interface{} `json:"data"`
+	Description    string                 `json:"description"`
+	Id             string                 `json:"id"`
+	OrganizationId string                 `json:"organizationId"`
+	ProjectId      string                 `json:"projectId"`
+	Status         IntegrationStatus      `json:"status"`
+	Updated        time.Time              `json:"updated"`
+}
+
+type GetIntegrationResponse struct {
+	Integration Integration `json:"integration"`
+}
+
+type ListIntegrationsResponse struct {
+	Integrations []Integration `json:"integrations"`
+}
+
+type CreateIntegrationRequest struct {
+	Data        map[string]interface{} `json:"data"`
+	Description string                 `json:"description"`
+}
+
+type CreateIntegrationResponse struct {
+	Id string `json:"id"`
+}
+
+type UpdateIntegrationRequest struct {
+	Data        *map[string]interface{} `json:"data,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+}
+
+type OpsGenieIntegrationData struct {
+	// API key used with the Ops Genie integration API
+	ApiKeyDisplay string `json:"apiKeyDisplay"`
+	// Required. Must be set to "opsGenie"
+	Sink string `json:"sink"`
+	// Source of data for integration
+	Source string `json:"source"`
+}
+
+type SlackIntegrationData struct {
+	// Slack Channel to send messages to
+	ChannelId string `json:"channelId"`
+	// API token for the Slack bot
+	TokenDisplay string `json:"tokenDisplay"`
+	// Required. Must be set to "slack"
+	Sink string `json:"sink"`
+	// Source of data for integration
+	Source string `json:"source"`
+}
+
+type CreateOpsGenieIntegrationData struct {
+	// API key used with the Ops Genie integration API
+	ApiKey string `json:"apiKey"`
+	// Required. Must be set to "opsGenie"
+	Sink   string  `json:"sink"`
+	Source *string `json:"source,omitempty"`
+}
+
+type CreateSlackIntegrationData struct {
+	// Slack Channel to send messages to
+	ChannelId string `json:"channelId"`
+	// API token for the Slack bot
+	Token string `json:"token"`
+	// Required. Must be set to "slack"
+	Sink   string  `json:"sink"`
+	Source *string `json:"source,omitempty"`
+}
+
+type UpdateOpsGenieIntegrationData struct {
+	// API key used with the Ops Genie integration API
+	ApiKey *string `json:"apiKey,omitempty"`
+}
+
+type UpdateSlackIntegrationData struct {
+	// Slack Channel to send messages to
+	ChannelId *string `json:"channelId,omitempty"`
+	// API token for the Slack bot
+	Token *string `json:"token,omitempty"`
+}
+
+// An RFC 7807 error
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Errors of individual fields of the request, by field
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// ListOrganizations lists the organizations accessible with the current
+// credentials
+//
+// GET /resources/v1/organizations
+func (c *Client) ListOrganizations(ctx context.Context) (*ListOrganizationsResponse, error) {
+	var result ListOrganizationsResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/resources/v1/organizations",
+		Activity: "listing organizations",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListProjects lists the projects of an organization
+//
+// GET /resources/v1/organizations/{organizationId}/projects
+func (c *Client) ListProjects(ctx context.Context, organizationId string) (*ListProjectsResponse, error) {
+	var result ListProjectsResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/resources/v1/organizations/" + url.PathEscape(organizationId) + "/projects",
+		Activity: "listing projects",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateProject creates a project
+//
+// POST /resources/v1/organizations/{organizationId}/projects
+func (c *Client) CreateProject(ctx context.Context, organizationId string, body *CreateProjectRequest) (*CreateProjectResponse, error) {
+	var result CreateProjectResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     "/resources/v1/organizations/" + url.PathEscape(organizationId) + "/projects",
+		Body:     body,
+		Activity: "creating project",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetProject gets a project
+//
+// GET /resources/v1/organizations/{organizationId}/projects/{projectId}
+func (c *Client) GetProject(ctx context.Context, organizationId string, projectId string) (*GetProjectResponse, error) {
+	var result GetProjectResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/resources/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId),
+		Activity: "getting project",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateProject renames a project
+//
+// PUT /resources/v1/organizations/{organizationId}/projects/{projectId}
+func (c *Client) UpdateProject(ctx context.Context, organizationId string, projectId string, body *UpdateProjectRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/resources/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId),
+		Body:     body,
+		Activity: "updating project",
+	}, nil)
+}
+
+// DeleteProject deletes a project, which must be empty
+//
+// DELETE /resources/v1/organizations/{organizationId}/projects/{projectId}
+func (c *Client) DeleteProject(ctx context.Context, organizationId string, projectId string) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodDelete,
+		Path:     "/resources/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId),
+		Activity: "deleting project",
+	}, nil)
+}
+
+// ListNetworks lists the networks of a project
+//
+// GET /infra/v1/organizations/{organizationId}/projects/{projectId}/networks
+func (c *Client) ListNetworks(ctx context.Context, organizationId string, projectId string) (*ListNetworksResponse, error) {
+	var result ListNetworksResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/networks",
+		Activity: "listing networks",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateNetwork creates a network
+//
+// POST /infra/v1/organizations/{organizationId}/projects/{projectId}/networks
+func (c *Client) CreateNetwork(ctx context.Context, organizationId string, projectId string, body *CreateNetworkRequest) (*CreateNetworkResponse, error) {
+	var result CreateNetworkResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/networks",
+		Body:     body,
+		Activity: "creating network",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetNetwork gets a network
+//
+// GET /infra/v1/organizations/{organizationId}/projects/{projectId}/networks/{networkId}
+func (c *Client) GetNetwork(ctx context.Context, organizationId string, projectId string, networkId string) (*GetNetworkResponse, error) {
+	var result GetNetworkResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/networks/" + url.PathEscape(networkId),
+		Activity: "getting network",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateNetwork renames a network
+//
+// PUT /infra/v1/organizations/{organizationId}/projects/{projectId}/networks/{networkId}
+func (c *Client) UpdateNetwork(ctx context.Context, organizationId string, projectId string, networkId string, body *UpdateNetworkRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/networks/" + url.PathEscape(networkId),
+		Body:     body,
+		Activity: "updating network",
+	}, nil)
+}
+
+// DeleteNetwork deletes a network
+//
+// DELETE /infra/v1/organizations/{organizationId}/projects/{projectId}/networks/{networkId}
+func (c *Client) DeleteNetwork(ctx context.Context, organizationId string, projectId string, networkId string) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodDelete,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/networks/" + url.PathEscape(networkId),
+		Activity: "deleting network",
+	}, nil)
+}
+
+// ListPeerings lists the peerings of a project
+//
+// GET /infra/v1/organizations/{organizationId}/projects/{projectId}/peerings
+func (c *Client) ListPeerings(ctx context.Context, organizationId string, projectId string) (*ListPeeringsResponse, error) {
+	var result ListPeeringsResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/peerings",
+		Activity: "listing peerings",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreatePeering creates a peering
+//
+// POST /infra/v1/organizations/{organizationId}/projects/{projectId}/peerings
+func (c *Client) CreatePeering(ctx context.Context, organizationId string, projectId string, body *CreatePeeringRequest) (*CreatePeeringResponse, error) {
+	var result CreatePeeringResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/peerings",
+		Body:     body,
+		Activity: "creating peering",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPeering gets a peering
+//
+// GET /infra/v1/organizations/{organizationId}/projects/{projectId}/peerings/{peeringId}
+func (c *Client) GetPeering(ctx context.Context, organizationId string, projectId string, peeringId string) (*GetPeeringResponse, error) {
+	var result GetPeeringResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/peerings/" + url.PathEscape(peeringId),
+		Activity: "getting peering",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdatePeering renames a peering
+//
+// PUT /infra/v1/organizations/{organizationId}/projects/{projectId}/peerings/{peeringId}
+func (c *Client) UpdatePeering(ctx context.Context, organizationId string, projectId string, peeringId string, body *UpdatePeeringRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/peerings/" + url.PathEscape(peeringId),
+		Body:     body,
+		Activity: "updating peering",
+	}, nil)
+}
+
+// DeletePeering deletes a peering
+//
+// DELETE /infra/v1/organizations/{organizationId}/projects/{projectId}/peerings/{peeringId}
+func (c *Client) DeletePeering(ctx context.Context, organizationId string, projectId string, peeringId string) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodDelete,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/peerings/" + url.PathEscape(peeringId),
+		Activity: "deleting peering",
+	}, nil)
+}
+
+// ListAcls lists the ACLs of a project
+//
+// GET /infra/v1/organizations/{organizationId}/projects/{projectId}/acls
+func (c *Client) ListAcls(ctx context.Context, organizationId string, projectId string) (*ListAclsResponse, error) {
+	var result ListAclsResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/acls",
+		Activity: "listing acls",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAcl creates an ACL
+//
+// POST /infra/v1/organizations/{organizationId}/projects/{projectId}/acls
+func (c *Client) CreateAcl(ctx context.Context, organizationId string, projectId string, body *CreateAclRequest) (*CreateAclResponse, error) {
+	var result CreateAclResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/acls",
+		Body:     body,
+		Activity: "creating acl",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAcl gets an ACL
+//
+// GET /infra/v1/organizations/{organizationId}/projects/{projectId}/acls/{aclId}
+func (c *Client) GetAcl(ctx context.Context, organizationId string, projectId string, aclId string) (*GetAclResponse, error) {
+	var result GetAclResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/acls/" + url.PathEscape(aclId),
+		Activity: "getting acl",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAcl changes the name or address ranges of an ACL
+//
+// PUT /infra/v1/organizations/{organizationId}/projects/{projectId}/acls/{aclId}
+func (c *Client) UpdateAcl(ctx context.Context, organizationId string, projectId string, aclId string, body *UpdateAclRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/acls/" + url.PathEscape(aclId),
+		Body:     body,
+		Activity: "updating acl",
+	}, nil)
+}
+
+// DeleteAcl deletes an ACL
+//
+// DELETE /infra/v1/organizations/{organizationId}/projects/{projectId}/acls/{aclId}
+func (c *Client) DeleteAcl(ctx context.Context, organizationId string, projectId string, aclId string) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodDelete,
+		Path:     "/infra/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/acls/" + url.PathEscape(aclId),
+		Activity: "deleting acl",
+	}, nil)
+}
+
+// ListManagedClusters lists the managed clusters of a project
+//
+// GET /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters
+func (c *Client) ListManagedClusters(ctx context.Context, organizationId string, projectId string) (*ListManagedClustersResponse, error) {
+	var result ListManagedClustersResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters",
+		Activity: "listing managed clusters",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateManagedCluster creates a managed cluster
+//
+// POST /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters
+func (c *Client) CreateManagedCluster(ctx context.Context, organizationId string, projectId string, body *CreateManagedClusterRequest) (*CreateManagedClusterResponse, error) {
+	var result CreateManagedClusterResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters",
+		Body:     body,
+		Activity: "creating managed cluster",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetManagedCluster gets a managed cluster
+//
+// GET /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters/{clusterId}
+func (c *Client) GetManagedCluster(ctx context.Context, organizationId string, projectId string, clusterId string) (*GetManagedClusterResponse, error) {
+	var result GetManagedClusterResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters/" + url.PathEscape(clusterId),
+		Activity: "getting managed cluster",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateManagedCluster changes the name or protection of a managed cluster
+//
+// PUT /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters/{clusterId}
+func (c *Client) UpdateManagedCluster(ctx context.Context, organizationId string, projectId string, clusterId string, body *UpdateManagedClusterRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters/" + url.PathEscape(clusterId),
+		Body:     body,
+		Activity: "updating cluster",
+	}, nil)
+}
+
+// DeleteManagedCluster deletes a managed cluster
+//
+// DELETE /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters/{clusterId}
+func (c *Client) DeleteManagedCluster(ctx context.Context, organizationId string, projectId string, clusterId string) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodDelete,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters/" + url.PathEscape(clusterId),
+		Activity: "deleting managed cluster",
+	}, nil)
+}
+
+// ResizeManagedCluster moves a managed cluster to another instance type
+//
+// PUT /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters/{clusterId}/commands/resize
+func (c *Client) ResizeManagedCluster(ctx context.Context, organizationId string, projectId string, clusterId string, body *ResizeManagedClusterRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters/" + url.PathEscape(clusterId) + "/commands/resize",
+		Body:     body,
+		Activity: "resizing managed cluster",
+	}, nil)
+}
+
+// UpgradeManagedCluster moves a managed cluster to another server version
+//
+// PUT /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters/{clusterId}/commands/upgrade
+func (c *Client) UpgradeManagedCluster(ctx context.Context, organizationId string, projectId string, clusterId string, body *UpgradeManagedClusterRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters/" + url.PathEscape(clusterId) + "/commands/upgrade",
+		Body:     body,
+		Activity: "upgrading managed cluster",
+	}, nil)
+}
+
+// ExpandManagedClusterDisk grows the disks of a managed cluster, or changes
+// their type
+//
+// PUT /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters/{clusterId}/disk/expand
+func (c *Client) ExpandManagedClusterDisk(ctx context.Context, organizationId string, projectId string, clusterId string, body *ExpandManagedClusterDiskRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters/" + url.PathEscape(clusterId) + "/disk/expand",
+		Body:     body,
+		Activity: "expanding disks for managed cluster",
+	}, nil)
+}
+
+// GetManagedClusterInitialCredentials gets the passwords generated when a
+// managed cluster was created, until they are cleared
+//
+// GET /mesdb/v1/organizations/{organizationId}/projects/{projectId}/clusters/{clusterId}/initialCredentials
+func (c *Client) GetManagedClusterInitialCredentials(ctx context.Context, organizationId string, projectId string, clusterId string) (*GetManagedClusterInitialCredentialsResponse, error) {
+	var result GetManagedClusterInitialCredentialsResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/mesdb/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/clusters/" + url.PathEscape(clusterId) + "/initialCredentials",
+		Activity: "getting cluster initial credentials",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListJobs lists the jobs of a project
+//
+// GET /orchestrate/v1/organizations/{organizationId}/projects/{projectId}/jobs
+func (c *Client) ListJobs(ctx context.Context, organizationId string, projectId string) (*ListJobsResponse, error) {
+	var result ListJobsResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/orchestrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/jobs",
+		Activity: "listing jobs",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateJob creates a job
+//
+// POST /orchestrate/v1/organizations/{organizationId}/projects/{projectId}/jobs
+func (c *Client) CreateJob(ctx context.Context, organizationId string, projectId string, body *CreateJobRequest) (*CreateJobResponse, error) {
+	var result CreateJobResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     "/orchestrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/jobs",
+		Body:     body,
+		Activity: "creating job",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetJob gets a job
+//
+// GET /orchestrate/v1/organizations/{organizationId}/projects/{projectId}/jobs/{jobId}
+func (c *Client) GetJob(ctx context.Context, organizationId string, projectId string, jobId string) (*GetJobResponse, error) {
+	var result GetJobResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/orchestrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/jobs/" + url.PathEscape(jobId),
+		Activity: "getting job",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteJob deletes a job
+//
+// DELETE /orchestrate/v1/organizations/{organizationId}/projects/{projectId}/jobs/{jobId}
+func (c *Client) DeleteJob(ctx context.Context, organizationId string, projectId string, jobId string) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodDelete,
+		Path:     "/orchestrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/jobs/" + url.PathEscape(jobId),
+		Activity: "deleting job",
+	}, nil)
+}
+
+// ListIntegrations lists the integrations of a project
+//
+// GET /integrate/v1/organizations/{organizationId}/projects/{projectId}/integrations
+func (c *Client) ListIntegrations(ctx context.Context, organizationId string, projectId string) (*ListIntegrationsResponse, error) {
+	var result ListIntegrationsResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/integrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/integrations",
+		Activity: "listing integrations",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateIntegration creates an integration
+//
+// POST /integrate/v1/organizations/{organizationId}/projects/{projectId}/integrations
+func (c *Client) CreateIntegration(ctx context.Context, organizationId string, projectId string, body *CreateIntegrationRequest) (*CreateIntegrationResponse, error) {
+	var result CreateIntegrationResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodPost,
+		Path:     "/integrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/integrations",
+		Body:     body,
+		Activity: "creating integration",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetIntegration gets an integration
+//
+// GET /integrate/v1/organizations/{organizationId}/projects/{projectId}/integrations/{integrationId}
+func (c *Client) GetIntegration(ctx context.Context, organizationId string, projectId string, integrationId string) (*GetIntegrationResponse, error) {
+	var result GetIntegrationResponse
+	if err := c.doer.Do(ctx, &Request{
+		Method:   http.MethodGet,
+		Path:     "/integrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/integrations/" + url.PathEscape(integrationId),
+		Activity: "getting integration",
+	}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateIntegration changes the description or data of an integration
+//
+// PUT /integrate/v1/organizations/{organizationId}/projects/{projectId}/integrations/{integrationId}
+func (c *Client) UpdateIntegration(ctx context.Context, organizationId string, projectId string, integrationId string, body *UpdateIntegrationRequest) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodPut,
+		Path:     "/integrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/integrations/" + url.PathEscape(integrationId),
+		Body:     body,
+		Activity: "updating integration",
+	}, nil)
+}
+
+// DeleteIntegration deletes an integration
+//
+// DELETE /integrate/v1/organizations/{organizationId}/projects/{projectId}/integrations/{integrationId}
+func (c *Client) DeleteIntegration(ctx context.Context, organizationId string, projectId string, integrationId string) error {
+	return c.doer.Do(ctx, &Request{
+		Method:   http.MethodDelete,
+		Path:     "/integrate/v1/organizations/" + url.PathEscape(organizationId) + "/projects/" + url.PathEscape(projectId) + "/integrations/" + url.PathEscape(integrationId),
+		Activity: "deleting integration",
+	}, nil)
+}
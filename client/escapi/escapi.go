@@ -0,0 +1,40 @@
+// Package escapi is the Event Store Cloud API as described by openapi.json.
+// The types and operations in escapi.gen.go are generated from it; change the
+// description and run go generate rather than editing them.
+//
+// The package only builds requests and decodes responses. Sending them,
+// authenticating, retrying and turning error responses into errors is left
+// to a Doer, which the client package provides.
+package escapi
+
+//go:generate go run ../../tools/apigen -spec openapi.json -out escapi.gen.go -package escapi
+
+import (
+	"context"
+)
+
+// Request is a single call against the API
+type Request struct {
+	Method string
+	// Path and query relative to the root of the API, already escaped
+	Path string
+	// Request payload, serialized as JSON when not nil
+	Body interface{}
+	// Describes the operation in error messages, e.g. "getting network"
+	Activity string
+}
+
+// Doer sends requests to the API. It decodes a successful JSON response into
+// result when it is not nil, and returns an error for any other response.
+type Doer interface {
+	Do(ctx context.Context, req *Request, result interface{}) error
+}
+
+// Client makes the calls described by the API
+type Client struct {
+	doer Doer
+}
+
+func NewClient(doer Doer) *Client {
+	return &Client{doer: doer}
+}
@@ -0,0 +1,104 @@
+package escapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+// recordingDoer keeps the requests it is given and answers with a canned
+// JSON response
+type recordingDoer struct {
+	requests []*escapi.Request
+	response string
+}
+
+func (d *recordingDoer) Do(ctx context.Context, req *escapi.Request, result interface{}) error {
+	d.requests = append(d.requests, req)
+	if result == nil || d.response == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(d.response), result)
+}
+
+func TestRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(context.Context, *escapi.Client) error
+		method   string
+		path     string
+		activity string
+		hasBody  bool
+	}{
+		{
+			name: "list integrations",
+			call: func(ctx context.Context, c *escapi.Client) error {
+				_, err := c.ListIntegrations(ctx, "org", "project")
+				return err
+			},
+			method:   http.MethodGet,
+			path:     "/integrate/v1/organizations/org/projects/project/integrations",
+			activity: "listing integrations",
+		},
+		{
+			name: "parameters are escaped",
+			call: func(ctx context.Context, c *escapi.Client) error {
+				_, err := c.GetNetwork(ctx, "org", "a/b", "c d?")
+				return err
+			},
+			method:   http.MethodGet,
+			path:     "/infra/v1/organizations/org/projects/a%2Fb/networks/c%20d%3F",
+			activity: "getting network",
+		},
+		{
+			name: "body",
+			call: func(ctx context.Context, c *escapi.Client) error {
+				return c.ResizeManagedCluster(ctx, "org", "project", "cluster", &escapi.ResizeManagedClusterRequest{TargetSize: "F1"})
+			},
+			method:   http.MethodPut,
+			path:     "/mesdb/v1/organizations/org/projects/project/clusters/cluster/commands/resize",
+			activity: "resizing managed cluster",
+			hasBody:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := &recordingDoer{}
+			if err := tt.call(context.Background(), escapi.NewClient(doer)); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(doer.requests) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(doer.requests))
+			}
+			req := doer.requests[0]
+			if req.Method != tt.method || req.Path != tt.path {
+				t.Errorf("expected %s %s, got %s %s", tt.method, tt.path, req.Method, req.Path)
+			}
+			if req.Activity != tt.activity {
+				t.Errorf("expected activity %q, got %q", tt.activity, req.Activity)
+			}
+			if (req.Body != nil) != tt.hasBody {
+				t.Errorf("expected a body: %v, got %#v", tt.hasBody, req.Body)
+			}
+		})
+	}
+}
+
+func TestResponseDecoding(t *testing.T) {
+	doer := &recordingDoer{response: `{"cluster":{"id":"cluster","diskSizeGb":16,"description":"name"}}`}
+
+	resp, err := escapi.NewClient(doer).GetManagedCluster(context.Background(), "org", "project", "cluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := resp.ManagedCluster
+	if cluster.ClusterID != "cluster" || cluster.DiskSizeGB != 16 || cluster.Name != "name" {
+		t.Errorf("unexpected cluster %#v", cluster)
+	}
+}
@@ -0,0 +1,14 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+// SetDeviceLoginSecond shortens the intervals of the device flow for the
+// duration of the test
+func SetDeviceLoginSecond(t testing.TB, second time.Duration) {
+	previous := deviceLoginSecond
+	deviceLoginSecond = second
+	t.Cleanup(func() { deviceLoginSecond = previous })
+}
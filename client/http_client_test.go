@@ -0,0 +1,187 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePEM writes a single PEM block to a file in dir, returning its path
+func writePEM(t *testing.T, dir string, name string, blockType string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeClientCertificate writes a self-signed client certificate and its
+// key, returning their paths
+func writeClientCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyData, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return writePEM(t, dir, "client.crt", "CERTIFICATE", cert), writePEM(t, dir, "client.key", "EC PRIVATE KEY", keyData)
+}
+
+func get(t *testing.T, config *Config, url string) (*http.Response, error) {
+	t.Helper()
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		t.Fatalf("creating HTTP client: %s", err)
+	}
+	resp, err := httpClient.Get(url)
+	if err == nil {
+		t.Cleanup(func() { _ = resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestHTTPClientTLS(t *testing.T) {
+	var clientCertificates [][]*x509.Certificate
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientCertificates = append(clientCertificates, r.TLS.PeerCertificates)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	// Rejecting the certificate is expected, not worth logging
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	certFile, keyFile := writeClientCertificate(t, dir)
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		if _, err := get(t, &Config{}, server.URL); err == nil || !strings.Contains(err.Error(), "certificate") {
+			t.Errorf("expected the server certificate to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("CA file", func(t *testing.T) {
+		if _, err := get(t, &Config{CAFile: caFile}, server.URL); err != nil {
+			t.Errorf("expected the CA file to be trusted: %s", err)
+		}
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		if _, err := get(t, &Config{InsecureSkipVerify: true}, server.URL); err != nil {
+			t.Errorf("expected the certificate not to be verified: %s", err)
+		}
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		clientCertificates = nil
+		if _, err := get(t, &Config{CAFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile}, server.URL); err != nil {
+			t.Fatal(err)
+		}
+		if len(clientCertificates) != 1 || len(clientCertificates[0]) != 1 || clientCertificates[0][0].Subject.CommonName != "client" {
+			t.Errorf("expected the client certificate to be presented, got %v", clientCertificates)
+		}
+	})
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeClientCertificate(t, dir)
+	notPEM := filepath.Join(dir, "not-pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		err    string
+	}{
+		{
+			name:   "missing CA file",
+			config: &Config{CAFile: filepath.Join(dir, "missing")},
+			err:    "error reading CA file",
+		},
+		{
+			name:   "CA file without certificates",
+			config: &Config{CAFile: notPEM},
+			err:    "no PEM certificates found",
+		},
+		{
+			name:   "client certificate without key",
+			config: &Config{ClientCertFile: certFile},
+			err:    "a client certificate and key must be set together",
+		},
+		{
+			name:   "client key without certificate",
+			config: &Config{ClientKeyFile: keyFile},
+			err:    "a client certificate and key must be set together",
+		},
+		{
+			name:   "mismatched client certificate and key",
+			config: &Config{ClientCertFile: keyFile, ClientKeyFile: certFile},
+			err:    "error loading client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newHTTPClient(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestHTTPClientProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	t.Cleanup(proxy.Close)
+
+	if _, err := get(t, &Config{ProxyURL: proxy.URL}, "http://api.example.invalid/organizations"); err != nil {
+		t.Fatalf("requesting through the proxy: %s", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://api.example.invalid/organizations" {
+		t.Errorf("expected the request to go through the proxy, got %v", proxied)
+	}
+
+	if _, err := newHTTPClient(&Config{ProxyURL: "http://proxy.example.com:port"}); err == nil || !strings.Contains(err.Error(), "invalid proxy URL") {
+		t.Errorf("expected an invalid proxy URL error, got %v", err)
+	}
+}
@@ -1,32 +1,16 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
 )
 
-type UpdateIntegrationRequest struct {
-	Data        *map[string]interface{} `json:"data,omitempty"`
-	Description *string                 `json:"description,omitempty"`
-}
-
-type UpdateOpsGenieIntegrationData struct {
-	// API key used with the Ops Genie integration API
-	ApiKey *string `json:"apiKey,omitempty"`
-}
-
-type UpdateSlackIntegrationData struct {
-	// Slack Channel to send messages to
-	ChannelId *string `json:"channelId,omitempty"`
-	// API token for the Slack bot
-	Token *string `json:"token,omitempty"`
-}
+type (
+	UpdateIntegrationRequest      = escapi.UpdateIntegrationRequest
+	UpdateOpsGenieIntegrationData = escapi.UpdateOpsGenieIntegrationData
+	UpdateSlackIntegrationData    = escapi.UpdateSlackIntegrationData
+)
 
 func (c *Client) UpdateIntegration(
 	ctx context.Context,
@@ -34,43 +18,6 @@ func (c *Client) UpdateIntegration(
 	projectId string,
 	integrationId string,
 	updateIntegrationRequest UpdateIntegrationRequest,
-) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	requestBody, err := json.Marshal(updateIntegrationRequest)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error marshalling request: %w", err))
-	}
-
-	url := *c.apiURL
-	url.Path = "/integrate/v1/organizations/{organizationId}/projects/{projectId}/integrations/{integrationId}"
-	url.Path = strings.Replace(url.Path, "{"+"organizationId"+"}", organizationId, -1)
-	url.Path = strings.Replace(url.Path, "{"+"projectId"+"}", projectId, -1)
-	url.Path = strings.Replace(url.Path, "{"+"integrationId"+"}", integrationId, -1)
-
-	request, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPut,
-		url.String(),
-		bytes.NewReader(requestBody),
-	)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error constructing request for UpdateIntegration: %w", err))
-	}
-	request.Header.Add("Content-Type", "application/json")
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error sending request for UpdateIntegration: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return translateStatusCode(resp.StatusCode, "UpdateIntegration", resp.Body)
-	}
-
-	return diags
+) error {
+	return c.api.UpdateIntegration(ctx, organizationId, projectId, integrationId, &updateIntegrationRequest)
 }
@@ -2,11 +2,6 @@ package client
 
 import (
 	"context"
-	"fmt"
-	"net/http"
-	"strings"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 func (c *Client) DeleteJob(
@@ -14,31 +9,6 @@ func (c *Client) DeleteJob(
 	organizationId string,
 	projectId string,
 	jobId string,
-) diag.Diagnostics {
-	url := *c.apiURL
-	url.Path = "/orchestrate/v1/organizations/{organizationId}/projects/{projectId}/jobs/{jobId}"
-	url.Path = strings.Replace(url.Path, "{"+"organizationId"+"}", organizationId, -1)
-	url.Path = strings.Replace(url.Path, "{"+"projectId"+"}", projectId, -1)
-	url.Path = strings.Replace(url.Path, "{"+"jobId"+"}", jobId, -1)
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, url.String(), nil)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error constructing request for DeleteJob: %w", err))
-	}
-	request.Header.Add("Content-Type", "application/json")
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error sending request for DeleteJob: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return translateStatusCode(resp.StatusCode, "DeleteJob", resp.Body)
-	}
-
-	return nil
+) error {
+	return c.api.DeleteJob(ctx, organizationId, projectId, jobId)
 }
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	// How long signing keys are cached before being fetched again
+	jwksCacheTTL = 1 * time.Hour
+	// Tokens signed with an unknown key trigger a fetch, at most this often,
+	// so a key rotation at the identity provider is picked up right away
+	jwksMinRefreshInterval = 1 * time.Minute
+	jwksFetchTimeout       = 30 * time.Second
+)
+
+// Audiences accepted on access tokens: the API itself, and the console
+// application which issues tokens to the Event Store Cloud CLI
+var acceptedAudiences = []string{apiAudience, "qB1dK9gAx6U1H1miH4LfwCp4Q1y3qSeZ"}
+
+// Signing algorithms accepted on access tokens. Symmetric algorithms and
+// `none` are never accepted, whatever the token header says.
+var acceptedAlgorithms = []jwa.SignatureAlgorithm{
+	jwa.RS256, jwa.RS384, jwa.RS512,
+	jwa.PS256, jwa.PS384, jwa.PS512,
+	jwa.ES256, jwa.ES384, jwa.ES512,
+}
+
+// jwksCache holds the signing keys and issuer of the identity provider, as
+// published in its OpenID Connect discovery document.
+type jwksCache struct {
+	mu        sync.Mutex
+	issuer    string
+	keys      jwk.Set
+	fetchedAt time.Time
+}
+
+type openIDConfiguration struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// validateToken checks the signature of token against the identity
+// provider's published keys, along with its expiry, issuer and audience.
+func (c *Client) validateToken(ctx context.Context, token accessToken) (jwt.Token, error) {
+	message, err := jws.ParseString(string(token))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing token: %w", err)
+	}
+	if len(message.Signatures()) != 1 {
+		return nil, errors.New("token must have exactly one signature")
+	}
+
+	headers := message.Signatures()[0].ProtectedHeaders()
+	alg := headers.Algorithm()
+	if !slices.Contains(acceptedAlgorithms, alg) {
+		return nil, fmt.Errorf("token is signed with unsupported algorithm %q", alg)
+	}
+
+	issuer, key, err := c.signingKey(ctx, headers.KeyID())
+	if err != nil {
+		return nil, err
+	}
+	if keyAlg := key.Algorithm(); keyAlg != "" && keyAlg != alg.String() {
+		return nil, fmt.Errorf("token algorithm %q does not match key %q", alg, headers.KeyID())
+	}
+
+	var rawKey interface{}
+	if err := key.Raw(&rawKey); err != nil {
+		return nil, fmt.Errorf("error reading key %q: %w", headers.KeyID(), err)
+	}
+
+	parsed, err := jwt.ParseString(string(token), jwt.WithVerify(alg, rawKey))
+	if err != nil {
+		return nil, fmt.Errorf("error verifying token: %w", err)
+	}
+
+	err = jwt.Validate(parsed, jwt.WithAcceptableSkew(30*time.Second), jwt.WithIssuer(issuer))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	for _, aud := range parsed.Audience() {
+		if slices.Contains(acceptedAudiences, aud) {
+			return parsed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("token audience %v is not one of %v", parsed.Audience(), acceptedAudiences)
+}
+
+// signingKey returns the key with the given ID along with the issuer it
+// belongs to. The keys are fetched again if they have expired or the key is
+// unknown.
+func (c *Client) signingKey(ctx context.Context, kid string) (string, jwk.Key, error) {
+	cache := c.jwks
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.keys == nil || time.Since(cache.fetchedAt) > jwksCacheTTL {
+		if err := c.fetchJWKS(ctx, cache); err != nil {
+			return "", nil, err
+		}
+	}
+
+	key, ok := lookupKey(cache.keys, kid)
+	if !ok && time.Since(cache.fetchedAt) > jwksMinRefreshInterval {
+		if err := c.fetchJWKS(ctx, cache); err != nil {
+			return "", nil, err
+		}
+		key, ok = lookupKey(cache.keys, kid)
+	}
+	if !ok {
+		return "", nil, fmt.Errorf("token is signed with unknown key %q", kid)
+	}
+
+	return cache.issuer, key, nil
+}
+
+// Tokens without a key ID are only accepted when the identity provider
+// publishes a single key.
+func lookupKey(keys jwk.Set, kid string) (jwk.Key, bool) {
+	if kid == "" {
+		if keys.Len() != 1 {
+			return nil, false
+		}
+		return keys.Get(0)
+	}
+
+	return keys.LookupKeyID(kid)
+}
+
+func (c *Client) fetchJWKS(ctx context.Context, cache *jwksCache) error {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	discoveryURL := *c.idpURL
+	discoveryURL.Path = strings.TrimSuffix(discoveryURL.Path, "/") + "/.well-known/openid-configuration"
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error constructing request: %w", err)
+	}
+
+	resp, err := c.do(request)
+	if err != nil {
+		return fmt.Errorf("error fetching OpenID configuration: %w", err)
+	}
+	defer closeIgnoreError(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error %d fetching OpenID configuration from %s", resp.StatusCode, discoveryURL.String())
+	}
+
+	config := openIDConfiguration{}
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return fmt.Errorf("error parsing OpenID configuration: %w", err)
+	}
+	if config.Issuer == "" || config.JWKSURI == "" {
+		return fmt.Errorf("OpenID configuration from %s has no issuer or JWKS URI", discoveryURL.String())
+	}
+
+	keysRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, config.JWKSURI, nil)
+	if err != nil {
+		return fmt.Errorf("error constructing request: %w", err)
+	}
+
+	keysResp, err := c.do(keysRequest)
+	if err != nil {
+		return fmt.Errorf("error fetching signing keys: %w", err)
+	}
+	defer closeIgnoreError(keysResp.Body)
+
+	if keysResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error %d fetching signing keys from %s", keysResp.StatusCode, config.JWKSURI)
+	}
+
+	keys, err := jwk.ParseReader(keysResp.Body)
+	if err != nil {
+		return fmt.Errorf("error parsing signing keys: %w", err)
+	}
+
+	cache.issuer = config.Issuer
+	cache.keys = keys
+	cache.fetchedAt = time.Now()
+
+	return nil
+}
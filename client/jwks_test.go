@@ -0,0 +1,73 @@
+package client_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+	"github.com/lestrrat-go/jwx/jwa"
+)
+
+func TestCachedTokensAreValidated(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  clienttest.Config
+		problem string
+	}{
+		{
+			name:    "other audience",
+			config:  clienttest.Config{TokenClaims: map[string]interface{}{"aud": []string{"https://other.example.com"}}},
+			problem: "token audience [https://other.example.com] is not one of",
+		},
+		{
+			name:    "other issuer",
+			config:  clienttest.Config{TokenClaims: map[string]interface{}{"iss": "https://other.example.com/"}},
+			problem: `"iss" not satisfied`,
+		},
+		{
+			name:    "unknown key",
+			config:  clienttest.Config{TokenKeyID: "rotated"},
+			problem: `token is signed with unknown key "rotated"`,
+		},
+		{
+			name:    "symmetric algorithm",
+			config:  clienttest.Config{TokenAlgorithm: jwa.HS256},
+			problem: `token is signed with unsupported algorithm "HS256"`,
+		},
+		{
+			name:    "unsigned",
+			config:  clienttest.Config{TokenAlgorithm: jwa.NoSignature},
+			problem: `token is signed with unsupported algorithm "none"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := clienttest.NewServer(&tt.config)
+			t.Cleanup(server.Close)
+			c := newFileStoreClient(t, server, t.TempDir())
+
+			if err := c.TokenRefresh(ctx, false); err != nil {
+				t.Fatalf("refreshing token: %s", err)
+			}
+
+			info, err := c.TokenInspect(ctx)
+			if err != nil {
+				t.Fatalf("inspecting token: %s", err)
+			}
+			if !strings.Contains(info.Problem, tt.problem) {
+				t.Errorf("expected problem %q, got %q", tt.problem, info.Problem)
+			}
+
+			// The cached token is never used, so another one is requested
+			if err := c.TokenRefresh(ctx, false); err != nil {
+				t.Fatalf("refreshing token: %s", err)
+			}
+			if issued := tokenRequests(server); issued != 2 {
+				t.Errorf("expected the cached token to be replaced, got %d token requests", issued)
+			}
+		})
+	}
+}
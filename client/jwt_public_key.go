@@ -1,22 +0,0 @@
-package client
-
-const jwtPublicKey = `-----BEGIN CERTIFICATE-----
-MIIDBzCCAe+gAwIBAgIJfWmV9++jvUgSMA0GCSqGSIb3DQEBCwUAMCExHzAdBgNV
-BAMTFmRldi0zc2dqZ2ItYy5hdXRoMC5jb20wHhcNMjAwNDAxMjIwMzU0WhcNMzMx
-MjA5MjIwMzU0WjAhMR8wHQYDVQQDExZkZXYtM3NnamdiLWMuYXV0aDAuY29tMIIB
-IjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAswuA/m+cwxYqyeTQN4mWSzDX
-n70WHJEU0gS0No+yKc9ISvaUL2iRpSKxwEZSv6IOSEzF/Qm6wudSPryBScX97Rsk
-XctjFhuKnFZLF5Cw/l5ILn7f1+uOQjhlQz/nLiN7osRWbVEqhTm4PE1BZlLxrn57
-QHWKPOMl2dt7xzrmB7wcD/iJRy1KyKcmbyrYFm8B6PON4vvn6fcjXSsjY6QknqjW
-Rvu1eoHvD/pnst2SFCykziqZUeEBhIZ4iPO3K22nABmWqlJGuLkXoAoT3J+dvaj/
-FmiobU0L3WtlV65RSdJsIu3fVFwpTCemW9/WOql5inmh1/7cilU0TtE8JTOgGwID
-AQABo0IwQDAPBgNVHRMBAf8EBTADAQH/MB0GA1UdDgQWBBRkq/rIzthN4LUZO4b+
-OkRo1G3NJzAOBgNVHQ8BAf8EBAMCAoQwDQYJKoZIhvcNAQELBQADggEBAFjGvzQx
-yfW7+P+kpYhIS1phAa4DNBLurrDm9mA5p2pp4PqOVx2gPrSVKAmuEUV7oCQh5eKI
-w4g604L/L4cpRTkkl9aVybROo3OV6757wtDK4fEe9fUM/n/72subARbBUwrWaHvJ
-jhAo9vBeHZxOA53Jy3xWTopNH5gKXtNiXpeaQ0tUgRUks3ghvjwyj33c04PdYTpT
-KhPLYSu5dzYqA6krZFHwjyknnb5sqDkJuyOS65KV7Mk74kNhnuKSH6dGYiKjRP3X
-e1aZDTL7XivQjdksmf2ZwJK/+g4MaPCDHBuDv40HkBEOr6FdjB3p89GhRVHrWLso
-9ChQRb+jDhB2gCc=
------END CERTIFICATE-----
-`
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Subsystem of the provider logs used by the client, so that its verbosity
+// can be set with TF_LOG_PROVIDER_EVENTSTORECLOUD_CLIENT
+const logSubsystem = "client"
+
+const redacted = "[REDACTED]"
+
+// Keys of JSON fields, form values and headers which are never logged, after
+// lowercasing and removing `-` and `_`
+var sensitiveKeys = map[string]bool{
+	"authorization":   true,
+	"accesstoken":     true,
+	"refreshtoken":    true,
+	"idtoken":         true,
+	"subjecttoken":    true,
+	"token":           true,
+	"devicecode":      true,
+	"clientsecret":    true,
+	"password":        true,
+	"adminpassword":   true,
+	"opspassword":     true,
+	"accesskeyid":     true,
+	"secretaccesskey": true,
+	"apikey":          true,
+}
+
+// IsSensitiveKey reports whether values of JSON fields, form values or
+// headers with the given name are secrets, which are never logged
+func IsSensitiveKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(key))
+	return sensitiveKeys[normalized]
+}
+
+func logDebug(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.SubsystemDebug(tflog.NewSubsystem(ctx, logSubsystem), logSubsystem, msg, fields...)
+}
+
+func logTrace(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.SubsystemTrace(tflog.NewSubsystem(ctx, logSubsystem), logSubsystem, msg, fields...)
+}
+
+func logWarn(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.SubsystemWarn(tflog.NewSubsystem(ctx, logSubsystem), logSubsystem, msg, fields...)
+}
+
+// do sends a single HTTP request, logging it along with its outcome. The
+// response body is read in full so that it can be logged, and replaced with
+// an in-memory copy.
+func (c *Client) do(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+
+	request.Header.Set("User-Agent", c.userAgent)
+	requestID, ok := RequestIDFromContext(ctx)
+	if !ok {
+		requestID = NewRequestID()
+	}
+	request.Header.Set("X-Request-Id", requestID)
+
+	fields := map[string]interface{}{
+		"http_method": request.Method,
+		"http_url":    request.URL.String(),
+		"request_id":  requestID,
+	}
+
+	if request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			if len(data) > 0 {
+				logTrace(ctx, "Sending HTTP request", fields, map[string]interface{}{
+					"http_request_headers": redactHeaders(request.Header),
+					"http_request_body":    redactBody(request.Header.Get("Content-Type"), data),
+				})
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(request)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		logDebug(ctx, "HTTP request failed", fields, map[string]interface{}{"error": err.Error()})
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	fields["http_status"] = resp.StatusCode
+
+	if err != nil {
+		logDebug(ctx, "Reading HTTP response failed", fields, map[string]interface{}{"error": err.Error()})
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	logDebug(ctx, "Received HTTP response", fields)
+	logTrace(ctx, "HTTP response body", fields, map[string]interface{}{
+		"http_response_headers": redactHeaders(resp.Header),
+		"http_response_body":    redactBody(resp.Header.Get("Content-Type"), data),
+	})
+
+	return resp, nil
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+	for key, values := range headers {
+		if IsSensitiveKey(key) {
+			result[key] = redacted
+			continue
+		}
+		result[key] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// redactBody renders a request or response body for the logs, with the
+// values of sensitive JSON fields and form values replaced
+func redactBody(contentType string, body []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType == "application/x-www-form-urlencoded" {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("[unparseable form, %d bytes]", len(body))
+		}
+		for key := range form {
+			if IsSensitiveKey(key) {
+				form.Set(key, redacted)
+			}
+		}
+		return form.Encode()
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		// Error pages from proxies and gateways aren't JSON but may still be
+		// worth reading
+		if mediaType == "application/json" {
+			return fmt.Sprintf("[unparseable JSON, %d bytes]", len(body))
+		}
+		return string(body)
+	}
+
+	redactedBody, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes]", len(body))
+	}
+	return string(redactedBody)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if IsSensitiveKey(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		secrets     []string
+		kept        []string
+	}{
+		{
+			name:        "token response",
+			contentType: "application/json",
+			body:        `{"access_token":"secret-access","refresh_token":"secret-refresh","id_token":"secret-id","token_type":"Bearer","expires_in":3600}`,
+			secrets:     []string{"secret-access", "secret-refresh", "secret-id"},
+			kept:        []string{`"token_type":"Bearer"`, `"expires_in":3600`},
+		},
+		{
+			name:        "nested JSON",
+			contentType: "application/json; charset=utf-8",
+			body: `{"cluster":{"name":"prod","credentials":{"adminPassword":"secret-admin","ops_password":"secret-ops"}},` +
+				`"integrations":[{"data":{"sink":"slack","token":"secret-slack"}},{"data":{"apiKey":"secret-opsgenie"}}],` +
+				`"aws":{"accessKeyId":"secret-key-id","secretAccessKey":"secret-key"}}`,
+			secrets: []string{"secret-admin", "secret-ops", "secret-slack", "secret-opsgenie", "secret-key-id", "secret-key"},
+			kept:    []string{`"name":"prod"`, `"sink":"slack"`},
+		},
+		{
+			name:        "sensitive object",
+			contentType: "application/json",
+			body:        `{"token":{"value":"secret-value"}}`,
+			secrets:     []string{"secret-value"},
+		},
+		{
+			name:        "problem details",
+			contentType: "application/problem+json",
+			body:        `{"title":"Bad Request","password":"secret-password"}`,
+			secrets:     []string{"secret-password"},
+			kept:        []string{"Bad Request"},
+		},
+		{
+			name:        "refresh token form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=refresh_token&client_id=public-client&refresh_token=secret-refresh",
+			secrets:     []string{"secret-refresh"},
+			kept:        []string{"grant_type=refresh_token", "client_id=public-client"},
+		},
+		{
+			name:        "client credentials form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=client_credentials&client_id=machine&client_secret=secret-client&audience=https%3A%2F%2Fapi.eventstore.cloud",
+			secrets:     []string{"secret-client"},
+			kept:        []string{"client_id=machine", "audience="},
+		},
+		{
+			name:        "token exchange form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=urn%3Aietf%3Aparams%3Aoauth%3Agrant-type%3Atoken-exchange&subject_token=secret-oidc&device_code=secret-device",
+			secrets:     []string{"secret-oidc", "secret-device"},
+			kept:        []string{"grant_type="},
+		},
+		{
+			name:        "unparseable JSON",
+			contentType: "application/json",
+			body:        `{"access_token":"secret-access"`,
+			secrets:     []string{"secret-access"},
+			kept:        []string{"[unparseable JSON, 31 bytes]"},
+		},
+		{
+			name:        "HTML error page",
+			contentType: "text/html",
+			body:        "<html><body>502 Bad Gateway</body></html>",
+			kept:        []string{"502 Bad Gateway"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logged := redactBody(tt.contentType, []byte(tt.body))
+
+			for _, secret := range tt.secrets {
+				if strings.Contains(logged, secret) {
+					t.Errorf("%q was logged: %s", secret, logged)
+				}
+			}
+			for _, kept := range tt.kept {
+				if !strings.Contains(logged, kept) {
+					t.Errorf("expected %q to be logged: %s", kept, logged)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer secret-access")
+	headers.Set("Api-Key", "secret-key")
+	headers.Set("X-Request-Id", "request-1")
+	headers.Add("Accept", "application/json")
+	headers.Add("Accept", "text/plain")
+
+	logged := redactHeaders(headers)
+
+	if logged["Authorization"] != redacted || logged["Api-Key"] != redacted {
+		t.Errorf("expected secret headers to be redacted, got %v", logged)
+	}
+	if logged["X-Request-Id"] != "request-1" || logged["Accept"] != "application/json, text/plain" {
+		t.Errorf("expected other headers to be logged, got %v", logged)
+	}
+}
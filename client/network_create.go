@@ -1,79 +1,32 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"path"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
 )
 
 type CreateNetworkRequest struct {
 	OrganizationID   string
 	ProjectID        string
-	ResourceProvider string `json:"provider"`
-	CidrBlock        string `json:"cidrBlock,omitempty"`
-	Name             string `json:"description"`
-	PublicAccess     bool   `json:"publicAccess,omitempty"`
-	Region           string `json:"region"`
+	ResourceProvider string
+	CidrBlock        string
+	Name             string
+	PublicAccess     bool
+	Region           string
 }
 
-type CreateNetworkResponse struct {
-	NetworkID string `json:"id"`
-}
+type CreateNetworkResponse = escapi.CreateNetworkResponse
 
 func (c *Client) NetworkCreate(
 	ctx context.Context,
 	req *CreateNetworkRequest,
-) (*CreateNetworkResponse, diag.Diagnostics) {
-	requestBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error marshalling request: %w", err))
-	}
-
-	requestURL := *c.apiURL
-	requestURL.Path = path.Join(
-		"infra",
-		"v1",
-		"organizations",
-		req.OrganizationID,
-		"projects",
-		req.ProjectID,
-		"networks",
-	)
-
-	request, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		requestURL.String(),
-		bytes.NewReader(requestBody),
-	)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error constructing request: %w", err))
-	}
-	request.Header.Add("Content-Type", "application/json")
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error sending request: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, translateStatusCode(resp.StatusCode, "creating network", resp.Body)
-	}
-
-	decoder := json.NewDecoder(resp.Body)
-	result := CreateNetworkResponse{}
-	if err := decoder.Decode(&result); err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error parsing response: %w", err))
-	}
-
-	return &result, nil
+) (*CreateNetworkResponse, error) {
+	return c.api.CreateNetwork(ctx, req.OrganizationID, req.ProjectID, &escapi.CreateNetworkRequest{
+		Provider:     req.ResourceProvider,
+		CidrBlock:    req.CidrBlock,
+		Description:  req.Name,
+		PublicAccess: req.PublicAccess,
+		Region:       req.Region,
+	})
 }
@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// Network updates used to be sent without a body, so the API rejected every
+// rename
+func TestNetworkUpdateSendsBody(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(nil)
+	t.Cleanup(server.Close)
+
+	c, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := c.ProjectCreate(ctx, &client.CreateProjectRequest{
+		OrganizationID: clienttest.DefaultOrganizationID,
+		Name:           "project",
+	})
+	if err != nil {
+		t.Fatalf("creating project: %s", err)
+	}
+	network, err := c.NetworkCreate(ctx, &client.CreateNetworkRequest{
+		OrganizationID:   clienttest.DefaultOrganizationID,
+		ProjectID:        project.ProjectID,
+		ResourceProvider: "aws",
+		CidrBlock:        "172.21.0.0/16",
+		Name:             "network",
+		Region:           "eu-west-1",
+	})
+	if err != nil {
+		t.Fatalf("creating network: %s", err)
+	}
+
+	err = c.NetworkUpdate(ctx, &client.UpdateNetworkRequest{
+		OrganizationID: clienttest.DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		NetworkID:      network.NetworkID,
+		Name:           "renamed",
+	})
+	if err != nil {
+		t.Fatalf("updating network: %s", err)
+	}
+
+	resp, err := c.NetworkGet(ctx, &client.GetNetworkRequest{
+		OrganizationID: clienttest.DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		NetworkID:      network.NetworkID,
+	})
+	if err != nil {
+		t.Fatalf("getting network: %s", err)
+	}
+	if resp.Network.Name != "renamed" {
+		t.Errorf("expected the network to be renamed, got %q", resp.Network.Name)
+	}
+}
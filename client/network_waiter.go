@@ -2,9 +2,8 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"time"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type WaitForNetworkStateRequest struct {
@@ -12,40 +11,34 @@ type WaitForNetworkStateRequest struct {
 	ProjectID      string
 	NetworkID      string
 	State          string
+	// Maximum time to wait for State, unbounded (apart from ctx) when zero
+	Timeout time.Duration
 }
 
 func (c *Client) NetworkWaitForState(
 	ctx context.Context,
 	req *WaitForNetworkStateRequest,
-) diag.Diagnostics {
-	start := time.Now()
-
+) error {
 	getRequest := &GetNetworkRequest{
 		OrganizationID: req.OrganizationID,
 		ProjectID:      req.ProjectID,
 		NetworkID:      req.NetworkID,
 	}
 
-	for {
+	description := fmt.Sprintf("network %s", req.NetworkID)
+
+	return Poll(ctx, &PollConfig{
+		Description: description,
+		Target:      []string{req.State},
+		Failures:    []PollFailure{{State: StateDefunct, GracePeriod: defunctGracePeriod}},
+		Timeout:     req.Timeout,
+		OnProgress:  logPollProgress(ctx, description),
+	}, func(ctx context.Context) (string, error) {
 		resp, err := c.NetworkGet(ctx, getRequest)
 		if err != nil {
-			return err
-		}
-
-		if resp.Network.Status == "defunct" {
-			// Resources in a `defunct` state may not update their status right
-			// away when being destroyed, so wait a bit before failing the operation.
-			elapsed := time.Since(start)
-			if elapsed.Seconds() > 30.0 {
-				return diag.Errorf("Network entered a defunct state!")
-			}
-		}
-
-		if resp.Network.Status != req.State {
-			time.Sleep(5 * time.Second)
-			continue
+			return "", err
 		}
 
-		return nil
-	}
+		return resp.Network.Status, nil
+	})
 }
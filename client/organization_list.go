@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+type (
+	Organization              = escapi.Organization
+	ListOrganizationsResponse = escapi.ListOrganizationsResponse
+)
+
+// OrganizationList returns the organizations accessible with the current
+// credentials
+func (c *Client) OrganizationList(ctx context.Context) (*ListOrganizationsResponse, error) {
+	return c.api.ListOrganizations(ctx)
+}
+
+// OrganizationDiscover returns the only organization accessible with the
+// current credentials, for callers which weren't told which one to use.
+// Errors start with setting, the way to choose an organization, and list the
+// organizations to choose from when there are several.
+func (c *Client) OrganizationDiscover(ctx context.Context, setting string) (*Organization, error) {
+	resp, err := c.OrganizationList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not set and organizations could not be listed: %w", setting, err)
+	}
+
+	switch len(resp.Organizations) {
+	case 0:
+		return nil, fmt.Errorf("%s is not set and no organization is accessible with the configured credentials", setting)
+	case 1:
+		return &resp.Organizations[0], nil
+	}
+
+	choices := make([]string, 0, len(resp.Organizations))
+	for _, org := range resp.Organizations {
+		choices = append(choices, fmt.Sprintf("  - %s (%s)", org.OrganizationID, org.Name))
+	}
+
+	return nil, fmt.Errorf(
+		"%s is not set and several organizations are accessible, set it to one of:\n%s",
+		setting,
+		strings.Join(choices, "\n"),
+	)
+}
@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestOrganizationDiscover(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("one organization", func(t *testing.T) {
+		server := clienttest.NewServer(nil)
+		t.Cleanup(server.Close)
+		c, err := server.NewClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		org, err := c.OrganizationDiscover(ctx, "organization_id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if org.OrganizationID != clienttest.DefaultOrganizationID {
+			t.Errorf("expected %s, got %s", clienttest.DefaultOrganizationID, org.OrganizationID)
+		}
+	})
+
+	t.Run("several organizations", func(t *testing.T) {
+		server := clienttest.NewServer(&clienttest.Config{
+			Organizations: []client.Organization{
+				{OrganizationID: "first", Name: "First"},
+				{OrganizationID: "second", Name: "Second"},
+			},
+		})
+		t.Cleanup(server.Close)
+		c, err := server.NewClient()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.OrganizationDiscover(ctx, "organization_id")
+		expected := "organization_id is not set and several organizations are accessible, set it to one of:\n" +
+			"  - first (First)\n" +
+			"  - second (Second)"
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		server := clienttest.NewServer(nil)
+		t.Cleanup(server.Close)
+		config := server.ClientConfig()
+		config.RefreshToken = "unknown"
+		c, err := client.New(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.OrganizationDiscover(ctx, "-org")
+		if err == nil || !strings.HasPrefix(err.Error(), "-org is not set and organizations could not be listed: ") {
+			t.Errorf("expected the listing error, got %v", err)
+		}
+	})
+}
@@ -1,80 +1,34 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"path"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
 )
 
 type CreatePeeringRequest struct {
 	OrganizationID        string
 	ProjectID             string
-	NetworkId             string   `json:"networkId"`
-	Name                  string   `json:"description"`
-	PeerAccountIdentifier string   `json:"peerAccountId"`
-	PeerNetworkIdentifier string   `json:"peerNetworkId"`
-	PeerNetworkRegion     string   `json:"peerNetworkRegion"`
-	Routes                []string `json:"routes"`
+	NetworkId             string
+	Name                  string
+	PeerAccountIdentifier string
+	PeerNetworkIdentifier string
+	PeerNetworkRegion     string
+	Routes                []string
 }
 
-type CreatePeeringResponse struct {
-	PeeringID string `json:"id"`
-}
+type CreatePeeringResponse = escapi.CreatePeeringResponse
 
 func (c *Client) PeeringCreate(
 	ctx context.Context,
 	req *CreatePeeringRequest,
-) (*CreatePeeringResponse, diag.Diagnostics) {
-	requestBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error marshalling request: %w", err))
-	}
-
-	requestURL := *c.apiURL
-	requestURL.Path = path.Join(
-		"infra",
-		"v1",
-		"organizations",
-		req.OrganizationID,
-		"projects",
-		req.ProjectID,
-		"peerings",
-	)
-
-	request, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		requestURL.String(),
-		bytes.NewReader(requestBody),
-	)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error constructing request: %w", err))
-	}
-	request.Header.Add("Content-Type", "application/json")
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error sending request: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, translateStatusCode(resp.StatusCode, "creating peering", resp.Body)
-	}
-
-	decoder := json.NewDecoder(resp.Body)
-	result := CreatePeeringResponse{}
-	if err := decoder.Decode(&result); err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error parsing response: %w", err))
-	}
-
-	return &result, nil
+) (*CreatePeeringResponse, error) {
+	return c.api.CreatePeering(ctx, req.OrganizationID, req.ProjectID, &escapi.CreatePeeringRequest{
+		NetworkId:         req.NetworkId,
+		Description:       req.Name,
+		PeerAccountId:     req.PeerAccountIdentifier,
+		PeerNetworkId:     req.PeerNetworkIdentifier,
+		PeerNetworkRegion: req.PeerNetworkRegion,
+		Routes:            req.Routes,
+	})
 }
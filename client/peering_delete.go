@@ -2,11 +2,6 @@ package client
 
 import (
 	"context"
-	"fmt"
-	"net/http"
-	"path"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type DeletePeeringRequest struct {
@@ -15,36 +10,6 @@ type DeletePeeringRequest struct {
 	PeeringID      string
 }
 
-func (c *Client) PeeringDelete(ctx context.Context, req *DeletePeeringRequest) diag.Diagnostics {
-	requestURL := *c.apiURL
-	requestURL.Path = path.Join(
-		"infra",
-		"v1",
-		"organizations",
-		req.OrganizationID,
-		"projects",
-		req.ProjectID,
-		"peerings",
-		req.PeeringID,
-	)
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, requestURL.String(), nil)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error constructing request: %w", err))
-	}
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error sending request: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return translateStatusCode(resp.StatusCode, "deleting peering", resp.Body)
-	}
-
-	return nil
+func (c *Client) PeeringDelete(ctx context.Context, req *DeletePeeringRequest) error {
+	return c.api.DeletePeering(ctx, req.OrganizationID, req.ProjectID, req.PeeringID)
 }
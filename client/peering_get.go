@@ -2,28 +2,11 @@ package client
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"path"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
 )
 
-type Peering struct {
-	ProjectID               string            `json:"projectId"`
-	PeeringID               string            `json:"id"`
-	NetworkID               string            `json:"networkId"`
-	Provider                string            `json:"provider"`
-	Name                    string            `json:"description"`
-	PeerAccountIdentifier   string            `json:"peerAccountId"`
-	PeerNetworkIdentifier   string            `json:"peerNetworkId"`
-	PeerNetworkRegion       string            `json:"peerNetworkRegion"`
-	ProviderPeeringMetadata map[string]string `json:"providerPeeringMetadata"`
-	Routes                  []string          `json:"routes"`
-	Status                  string            `json:"status"`
-	Created                 string            `json:"created,omitempty"`
-}
+type Peering = escapi.Peering
 
 type GetPeeringRequest struct {
 	OrganizationID string
@@ -31,49 +14,11 @@ type GetPeeringRequest struct {
 	PeeringID      string
 }
 
-type GetPeeringResponse struct {
-	Peering Peering `json:"peering"`
-}
+type GetPeeringResponse = escapi.GetPeeringResponse
 
 func (c *Client) PeeringGet(
 	ctx context.Context,
 	req *GetPeeringRequest,
-) (*GetPeeringResponse, diag.Diagnostics) {
-	requestURL := *c.apiURL
-	requestURL.Path = path.Join(
-		"infra",
-		"v1",
-		"organizations",
-		req.OrganizationID,
-		"projects",
-		req.ProjectID,
-		"peerings",
-		req.PeeringID,
-	)
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error constructing request: %w", err))
-	}
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return nil, err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error sending request: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, translateStatusCode(resp.StatusCode, "getting peering", resp.Body)
-	}
-
-	decoder := json.NewDecoder(resp.Body)
-	result := GetPeeringResponse{}
-	if err := decoder.Decode(&result); err != nil {
-		return nil, diag.FromErr(fmt.Errorf("error parsing response: %w", err))
-	}
-
-	return &result, nil
+) (*GetPeeringResponse, error) {
+	return c.api.GetPeering(ctx, req.OrganizationID, req.ProjectID, req.PeeringID)
 }
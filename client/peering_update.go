@@ -1,64 +1,20 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"path"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
 )
 
 type UpdatePeeringRequest struct {
 	OrganizationID string
 	ProjectID      string
 	PeeringID      string
-	Name           string `json:"description"`
+	Name           string
 }
 
-func (c *Client) PeeringUpdate(ctx context.Context, req *UpdatePeeringRequest) diag.Diagnostics {
-	requestBody, err := json.Marshal(req)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error marshalling request: %w", err))
-	}
-
-	requestURL := *c.apiURL
-	requestURL.Path = path.Join(
-		"infra",
-		"v1",
-		"organizations",
-		req.OrganizationID,
-		"projects",
-		req.ProjectID,
-		"peerings",
-		req.PeeringID,
-	)
-
-	request, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPut,
-		requestURL.String(),
-		bytes.NewReader(requestBody),
-	)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error constructing request: %w", err))
-	}
-	request.Header.Add("Content-Type", "application/json")
-	if err := c.addAuthorizationHeader(request); err != nil {
-		return err
-	}
-
-	resp, err := c.httpClient.Do(request)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error sending request: %w", err))
-	}
-	defer closeIgnoreError(resp.Body)
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return translateStatusCode(resp.StatusCode, "updating peering", resp.Body)
-	}
-
-	return nil
+func (c *Client) PeeringUpdate(ctx context.Context, req *UpdatePeeringRequest) error {
+	return c.api.UpdatePeering(ctx, req.OrganizationID, req.ProjectID, req.PeeringID, &escapi.UpdatePeeringRequest{
+		Description: req.Name,
+	})
 }
@@ -2,68 +2,76 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"time"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
+// Reported while a peering has reached its target state but the provider has
+// not yet filled in the metadata needed to complete the remote side
+const stateAwaitingProviderMetadata = "awaiting provider metadata"
+
 type WaitForPeeringStateRequest struct {
 	OrganizationID string
 	ProjectID      string
 	PeeringID      string
 	State          string
+	// Maximum time to wait for State, unbounded (apart from ctx) when zero
+	Timeout time.Duration
 }
 
 func (c *Client) PeeringWaitForState(
 	ctx context.Context,
 	req *WaitForPeeringStateRequest,
-) (*Peering, diag.Diagnostics) {
-	start := time.Now()
+) (*Peering, error) {
 	getRequest := &GetPeeringRequest{
 		OrganizationID: req.OrganizationID,
 		ProjectID:      req.ProjectID,
 		PeeringID:      req.PeeringID,
 	}
 
-	for {
+	description := fmt.Sprintf("peering %s", req.PeeringID)
+
+	var peering *Peering
+	err := Poll(ctx, &PollConfig{
+		Description: description,
+		Target:      []string{req.State},
+		Failures:    []PollFailure{{State: StateDefunct, GracePeriod: defunctGracePeriod}},
+		Timeout:     req.Timeout,
+		OnProgress:  logPollProgress(ctx, description),
+	}, func(ctx context.Context) (string, error) {
 		resp, err := c.PeeringGet(ctx, getRequest)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
+		peering = &resp.Peering
 
-		if resp.Peering.Status == "defunct" {
-			// Resources in a `defunct` state may not update their status right
-			// away when being destroyed, so wait a bit before failing the operation.
-			elapsed := time.Since(start)
-			if elapsed.Seconds() > 30.0 {
-				return nil, diag.Errorf("Peering entered a defunct state!")
-			}
+		if peering.Status != req.State || req.State == StateDeleted {
+			return peering.Status, nil
 		}
 
-		if resp.Peering.Status != req.State {
-			time.Sleep(5 * time.Second)
-			continue
+		if !hasProviderPeeringMetadata(peering) {
+			return stateAwaitingProviderMetadata, nil
 		}
 
-		if req.State == "deleted" {
-			return &resp.Peering, nil
-		}
+		return peering.Status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		switch resp.Peering.Provider {
-		case "aws":
-			if _, has := resp.Peering.ProviderPeeringMetadata["peeringLinkId"]; !has {
-				time.Sleep(5 * time.Second)
-				continue
-			}
-		case "gcp":
-			_, hasProject := resp.Peering.ProviderPeeringMetadata["projectId"]
-			_, hasNetwork := resp.Peering.ProviderPeeringMetadata["networkId"]
-			if !hasProject || !hasNetwork {
-				time.Sleep(5 * time.Second)
-				continue
-			}
-		}
+	return peering, nil
+}
 
-		return &resp.Peering, nil
+func hasProviderPeeringMetadata(peering *Peering) bool {
+	switch peering.Provider {
+	case "aws":
+		_, has := peering.ProviderPeeringMetadata["peeringLinkId"]
+		return has
+	case "gcp":
+		_, hasProject := peering.ProviderPeeringMetadata["projectId"]
+		_, hasNetwork := peering.ProviderPeeringMetadata["networkId"]
+		return hasProject && hasNetwork
 	}
+
+	return true
 }
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPollMinInterval = 2 * time.Second
+	defaultPollMaxInterval = 15 * time.Second
+	pollBackoffFactor      = 1.5
+)
+
+// Resources in a `defunct` state may not update their status right away when
+// being destroyed, so wait a bit before failing the operation.
+const defunctGracePeriod = 30 * time.Second
+
+// PollRefreshFunc fetches the current state of the resource being waited on.
+// Returning an error stops the poll immediately.
+type PollRefreshFunc func(ctx context.Context) (string, error)
+
+// PollFailure fails the poll once the resource has been observed in State for
+// longer than GracePeriod.
+type PollFailure struct {
+	State       string
+	GracePeriod time.Duration
+}
+
+type PollConfig struct {
+	// Human-friendly name of the resource, used in error messages
+	Description string
+	// States which complete the poll successfully
+	Target []string
+	// States which are expected on the way to Target. When empty, any state
+	// which isn't a target or a failure keeps the poll going.
+	Pending []string
+	// States which fail the poll after a grace period
+	Failures []PollFailure
+	// Bounds for the exponential backoff between refreshes
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// Upper bound for the whole poll, on top of any deadline carried by ctx
+	Timeout time.Duration
+	// Called after every refresh with the observed state
+	OnProgress func(state string, elapsed time.Duration)
+}
+
+// Poll calls refresh until it reports one of the target states, a failure
+// rule triggers, or ctx is done. Cancellation and deadlines interrupt the wait
+// between refreshes as well as any in-flight request.
+func Poll(ctx context.Context, config *PollConfig, refresh PollRefreshFunc) error {
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	interval := config.MinInterval
+	if interval <= 0 {
+		interval = defaultPollMinInterval
+	}
+	maxInterval := config.MaxInterval
+	if maxInterval < interval {
+		maxInterval = max(defaultPollMaxInterval, interval)
+	}
+
+	start := time.Now()
+	lastState := ""
+	enteredState := start
+
+	for {
+		state, err := refresh(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return pollInterrupted(ctx, config, lastState)
+			}
+			return err
+		}
+
+		now := time.Now()
+		if state != lastState {
+			lastState = state
+			enteredState = now
+		}
+
+		if config.OnProgress != nil {
+			config.OnProgress(state, now.Sub(start))
+		}
+
+		if slices.Contains(config.Target, state) {
+			return nil
+		}
+
+		for _, failure := range config.Failures {
+			if failure.State == state && now.Sub(enteredState) >= failure.GracePeriod {
+				return fmt.Errorf("%s entered a %s state!", capitalize(config.Description), state)
+			}
+		}
+
+		if len(config.Pending) > 0 && !slices.Contains(config.Pending, state) && !isFailureState(config, state) {
+			return fmt.Errorf(
+				"%s entered unexpected state %q while waiting for %s",
+				capitalize(config.Description),
+				state,
+				strings.Join(config.Target, ", "),
+			)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return pollInterrupted(ctx, config, lastState)
+		case <-timer.C:
+		}
+
+		interval = nextPollInterval(interval, maxInterval)
+	}
+}
+
+// nextPollInterval backs off from interval, up to maxInterval
+func nextPollInterval(interval, maxInterval time.Duration) time.Duration {
+	return min(time.Duration(float64(interval)*pollBackoffFactor), maxInterval)
+}
+
+func pollInterrupted(ctx context.Context, config *PollConfig, lastState string) error {
+	lastSeen := "before its state could be read"
+	if lastState != "" {
+		lastSeen = fmt.Sprintf("last seen in state %q", lastState)
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf(
+			"timed out waiting for %s to reach state %s, %s",
+			config.Description,
+			strings.Join(config.Target, ", "),
+			lastSeen,
+		)
+	}
+
+	return fmt.Errorf("cancelled waiting for %s, %s", config.Description, lastSeen)
+}
+
+func isFailureState(config *PollConfig, state string) bool {
+	for _, failure := range config.Failures {
+		if failure.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func logPollProgress(ctx context.Context, description string) func(string, time.Duration) {
+	return func(state string, elapsed time.Duration) {
+		logDebug(ctx, fmt.Sprintf("%s is %q", capitalize(description), state), map[string]interface{}{
+			"state":     state,
+			"elapsed_s": int(elapsed.Seconds()),
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// refreshStates returns a refresh function reporting each of states in turn, and
+// the last one forever after, along with the number of refreshes made
+func refreshStates(states ...string) (PollRefreshFunc, *int) {
+	calls := 0
+	return func(ctx context.Context) (string, error) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+		return state, nil
+	}, &calls
+}
+
+func TestPollReachesTarget(t *testing.T) {
+	refresh, calls := refreshStates("provisioning", "provisioning", "available")
+	var seen []string
+
+	err := Poll(context.Background(), &PollConfig{
+		Description: "cluster",
+		Target:      []string{"available"},
+		Pending:     []string{"provisioning"},
+		MinInterval: time.Millisecond,
+		OnProgress: func(state string, elapsed time.Duration) {
+			seen = append(seen, state)
+		},
+	}, refresh)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 refreshes, got %d", *calls)
+	}
+	if strings.Join(seen, ",") != "provisioning,provisioning,available" {
+		t.Errorf("unexpected progress %v", seen)
+	}
+}
+
+func TestPollFailsOnUnexpectedState(t *testing.T) {
+	refresh, calls := refreshStates("provisioning", "deleted", "available")
+
+	err := Poll(context.Background(), &PollConfig{
+		Description: "cluster",
+		Target:      []string{"available"},
+		Pending:     []string{"provisioning"},
+		MinInterval: time.Millisecond,
+	}, refresh)
+
+	expected := `Cluster entered unexpected state "deleted" while waiting for available`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+	if *calls != 2 {
+		t.Errorf("expected the poll to stop at the unexpected state, got %d refreshes", *calls)
+	}
+}
+
+func TestPollFailureGracePeriod(t *testing.T) {
+	config := func() *PollConfig {
+		return &PollConfig{
+			Description: "cluster",
+			Target:      []string{"available"},
+			Pending:     []string{"provisioning"},
+			Failures:    []PollFailure{{State: "defunct", GracePeriod: 50 * time.Millisecond}},
+			MinInterval: 5 * time.Millisecond,
+			MaxInterval: 5 * time.Millisecond,
+		}
+	}
+
+	t.Run("stays in failure state", func(t *testing.T) {
+		refresh, calls := refreshStates("provisioning", "defunct")
+		start := time.Now()
+
+		err := Poll(context.Background(), config(), refresh)
+
+		if err == nil || err.Error() != "Cluster entered a defunct state!" {
+			t.Fatalf("expected the defunct state to fail the poll, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("expected the poll to fail after the grace period, failed after %s", elapsed)
+		}
+		if *calls < 3 {
+			t.Errorf("expected the defunct state to be read several times, got %d refreshes", *calls)
+		}
+	})
+
+	t.Run("recovers within grace period", func(t *testing.T) {
+		refresh, _ := refreshStates("defunct", "defunct", "available")
+
+		if err := Poll(context.Background(), config(), refresh); err != nil {
+			t.Errorf("expected the poll to succeed, got %v", err)
+		}
+	})
+
+	t.Run("no grace period", func(t *testing.T) {
+		refresh, calls := refreshStates("defunct")
+		c := config()
+		c.Failures[0].GracePeriod = 0
+
+		if err := Poll(context.Background(), c, refresh); err == nil {
+			t.Error("expected the defunct state to fail the poll")
+		}
+		if *calls != 1 {
+			t.Errorf("expected the poll to fail on the first refresh, got %d refreshes", *calls)
+		}
+	})
+}
+
+func TestPollReturnsRefreshErrors(t *testing.T) {
+	refreshErr := errors.New("error getting cluster")
+
+	err := Poll(context.Background(), &PollConfig{Description: "cluster", Target: []string{"available"}}, func(ctx context.Context) (string, error) {
+		return "", refreshErr
+	})
+
+	if !errors.Is(err, refreshErr) {
+		t.Errorf("expected the refresh error, got %v", err)
+	}
+}
+
+func TestPollCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	refresh, _ := refreshStates("provisioning")
+	done := make(chan error)
+
+	go func() {
+		done <- Poll(ctx, &PollConfig{
+			Description: "cluster",
+			Target:      []string{"available"},
+			MinInterval: time.Hour,
+			OnProgress: func(state string, elapsed time.Duration) {
+				cancel()
+			},
+		}, refresh)
+	}()
+
+	select {
+	case err := <-done:
+		expected := `cancelled waiting for cluster, last seen in state "provisioning"`
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %q, got %v", expected, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected cancellation to interrupt the wait between refreshes")
+	}
+}
+
+func TestPollTimeout(t *testing.T) {
+	t.Run("names the last state", func(t *testing.T) {
+		refresh, _ := refreshStates("provisioning", "initializing")
+
+		err := Poll(context.Background(), &PollConfig{
+			Description: "cluster",
+			Target:      []string{"available"},
+			MinInterval: time.Millisecond,
+			MaxInterval: time.Millisecond,
+			Timeout:     30 * time.Millisecond,
+		}, refresh)
+
+		expected := `timed out waiting for cluster to reach state available, last seen in state "initializing"`
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("before the first read", func(t *testing.T) {
+		err := Poll(context.Background(), &PollConfig{
+			Description: "cluster",
+			Target:      []string{"available"},
+			Timeout:     10 * time.Millisecond,
+		}, func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+
+		expected := "timed out waiting for cluster to reach state available, before its state could be read"
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %q, got %v", expected, err)
+		}
+	})
+}
+
+func TestPollIntervalGrowsToMaximum(t *testing.T) {
+	interval := 2 * time.Second
+	var intervals []time.Duration
+	for i := 0; i < 7; i++ {
+		interval = nextPollInterval(interval, 15*time.Second)
+		intervals = append(intervals, interval)
+	}
+
+	expected := []time.Duration{
+		3 * time.Second,
+		4500 * time.Millisecond,
+		6750 * time.Millisecond,
+		10125 * time.Millisecond,
+		15 * time.Second,
+		15 * time.Second,
+		15 * time.Second,
+	}
+	for i := range expected {
+		if intervals[i] != expected[i] {
+			t.Fatalf("expected intervals %v, got %v", expected, intervals)
+		}
+	}
+
+	// The waits between refreshes follow the same progression
+	refresh, _ := refreshStates("provisioning", "provisioning", "provisioning", "provisioning", "available")
+	var refreshedAt []time.Time
+	err := Poll(context.Background(), &PollConfig{
+		Description: "cluster",
+		Target:      []string{"available"},
+		MinInterval: 10 * time.Millisecond,
+		MaxInterval: 20 * time.Millisecond,
+		OnProgress: func(state string, elapsed time.Duration) {
+			refreshedAt = append(refreshedAt, time.Now())
+		},
+	}, refresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, minWait := range []time.Duration{10, 15, 20, 20} {
+		if wait := refreshedAt[i+1].Sub(refreshedAt[i]); wait < minWait*time.Millisecond {
+			t.Errorf("expected refresh %d to wait at least %dms, waited %s", i+2, minWait, wait)
+		}
+	}
+}
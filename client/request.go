@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+const (
+	defaultRetryMaxAttempts = 5
+	defaultRetryWaitMin     = 1 * time.Second
+	defaultRetryWaitMax     = 30 * time.Second
+)
+
+// apiRequest describes a single call against the Event Store Cloud API
+type apiRequest struct {
+	method string
+	url    string
+	// Request payload, serialized as JSON when not nil
+	body interface{}
+	// Used to describe the operation in error messages, e.g. "getting network"
+	activity string
+}
+
+// apiDoer sends the calls of the generated client, resolving their paths
+// against the configured API URL
+type apiDoer struct {
+	client *Client
+}
+
+func (d apiDoer) Do(ctx context.Context, req *escapi.Request, result interface{}) error {
+	ref, err := url.Parse(req.Path)
+	if err != nil {
+		return fmt.Errorf("error constructing request: %w", err)
+	}
+
+	return d.client.execute(ctx, &apiRequest{
+		method:   req.Method,
+		url:      d.client.apiURL.ResolveReference(ref).String(),
+		body:     req.Body,
+		activity: req.Activity,
+	}, result)
+}
+
+// execute sends the request, retrying transient failures, and decodes a
+// successful JSON response into result when it is not nil.
+func (c *Client) execute(ctx context.Context, req *apiRequest, result interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer closeIgnoreError(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return translateStatusCode(resp.StatusCode, req.activity, resp.Body)
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if err := decoder.Decode(result); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+
+	return nil
+}
+
+// send performs the request and returns the final response, whatever its
+// status code. Transient failures are retried with jittered exponential
+// backoff. The caller is responsible for closing the response body.
+func (c *Client) send(ctx context.Context, req *apiRequest) (*http.Response, error) {
+	var requestBody []byte
+	if req.body != nil {
+		var err error
+		requestBody, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling request: %w", err)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, req.method, req.url, bytes.NewReader(requestBody))
+		if err != nil {
+			return nil, fmt.Errorf("error constructing request: %w", err)
+		}
+		if req.body != nil {
+			request.Header.Add("Content-Type", "application/json")
+		}
+		if err := c.addAuthorizationHeader(request); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.do(request)
+
+		retry := attempt < c.retryMaxAttempts && shouldRetry(req.method, resp, err)
+		var wait time.Duration
+		if retry {
+			wait = c.backoff(attempt, resp)
+			// Waiting past the deadline would only turn the API's answer
+			// into a timeout
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				retry = false
+			}
+		}
+		if !retry {
+			if err != nil {
+				return nil, fmt.Errorf("error sending request: %w", err)
+			}
+			return resp, nil
+		}
+
+		fields := map[string]interface{}{
+			"http_method": req.method,
+			"http_url":    req.url,
+			"attempt":     attempt,
+			"retry_in_ms": wait.Milliseconds(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["http_status"] = resp.StatusCode
+			_ = resp.Body.Close()
+		}
+		logDebug(ctx, fmt.Sprintf("Retrying %s", req.activity), fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("error sending request: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry decides whether a failed attempt is worth repeating. Only
+// idempotent methods are retried after server errors or dropped connections;
+// a 429 means the API refused the request outright, so any method may retry.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return isIdempotent(method) && isTransientNetworkError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header sent by the API takes precedence over the computed delay, up to the
+// maximum wait.
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, c.retryWaitMax)
+		}
+	}
+
+	wait := c.retryWaitMin << (attempt - 1)
+	if wait <= 0 || wait > c.retryWaitMax {
+		wait = c.retryWaitMax
+	}
+
+	// Full jitter keeps parallel Terraform operations from retrying in
+	// lockstep, without ever waiting longer than the maximum
+	return min(c.retryWaitMin/2+rand.N(wait), c.retryWaitMax)
+}
+
+// parseRetryAfter accepts both forms allowed by RFC 9110: a number of seconds
+// or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
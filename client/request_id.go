@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID attaches a correlation ID to ctx. Every API call made with
+// the returned context sends it as X-Request-Id, so that all the calls made
+// for one operation can be traced together by Event Store Cloud support.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the correlation ID attached to ctx, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok && requestID != ""
+}
+
+// NewRequestID generates a random correlation ID
+func NewRequestID() string {
+	requestID, err := uuid.GenerateUUID()
+	if err != nil {
+		// Only fails when the system has no source of randomness, in which
+		// case a missing correlation ID is the least of our problems
+		return ""
+	}
+	return requestID
+}
@@ -0,0 +1,70 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestTraceLogsHoldNoSecrets(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(config *client.Config)
+		secret    string
+	}{
+		{
+			name:      "refresh token",
+			configure: func(config *client.Config) {},
+			secret:    clienttest.RefreshToken,
+		},
+		{
+			name: "client secret",
+			configure: func(config *client.Config) {
+				config.ClientID = "machine"
+				config.ClientSecret = "test-client-secret"
+			},
+			secret: "test-client-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := clienttest.NewServer(&clienttest.Config{ClientSecrets: map[string]string{"machine": "test-client-secret"}})
+			t.Cleanup(server.Close)
+			config := server.ClientConfig()
+			tt.configure(config)
+			c, err := client.New(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var logs bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &logs)
+			if _, err := c.OrganizationList(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			logged := logs.String()
+			if !strings.Contains(logged, "/oauth/token") || !strings.Contains(logged, "/resources/v1/organizations") {
+				t.Fatalf("expected both calls to be logged, got %s", logged)
+			}
+			if !strings.Contains(logged, "http_request_body") || !strings.Contains(logged, "http_request_headers") {
+				t.Fatalf("expected bodies and headers to be logged, got %s", logged)
+			}
+			if !strings.Contains(logged, "[REDACTED]") {
+				t.Errorf("expected secrets to be redacted, got %s", logged)
+			}
+			// Access tokens are JWTs, which always start with the encoding of `{"`
+			for _, secret := range []string{tt.secret, "eyJ"} {
+				if strings.Contains(logged, secret) {
+					t.Errorf("%q was logged: %s", secret, logged)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	idempotent := []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}
+	other := []string{http.MethodPost, http.MethodPatch}
+
+	timeout := &url.Error{Op: "Get", URL: "https://api.eventstore.cloud", Err: os.ErrDeadlineExceeded}
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		// Whether idempotent and other methods are retried
+		idempotent bool
+		other      bool
+	}{
+		{name: "429", resp: &http.Response{StatusCode: http.StatusTooManyRequests}, idempotent: true, other: true},
+		{name: "502", resp: &http.Response{StatusCode: http.StatusBadGateway}, idempotent: true},
+		{name: "503", resp: &http.Response{StatusCode: http.StatusServiceUnavailable}, idempotent: true},
+		{name: "504", resp: &http.Response{StatusCode: http.StatusGatewayTimeout}, idempotent: true},
+		{name: "500", resp: &http.Response{StatusCode: http.StatusInternalServerError}},
+		{name: "501", resp: &http.Response{StatusCode: http.StatusNotImplemented}},
+		{name: "200", resp: &http.Response{StatusCode: http.StatusOK}},
+		{name: "400", resp: &http.Response{StatusCode: http.StatusBadRequest}},
+		{name: "401", resp: &http.Response{StatusCode: http.StatusUnauthorized}},
+		{name: "404", resp: &http.Response{StatusCode: http.StatusNotFound}},
+		{name: "409", resp: &http.Response{StatusCode: http.StatusConflict}},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, idempotent: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, idempotent: true},
+		{name: "unexpected EOF", err: fmt.Errorf("reading response: %w", io.ErrUnexpectedEOF), idempotent: true},
+		{name: "EOF", err: &url.Error{Op: "Get", URL: "https://api.eventstore.cloud", Err: io.EOF}, idempotent: true},
+		{name: "timeout", err: timeout, idempotent: true},
+		{name: "cancelled", err: &url.Error{Op: "Get", URL: "https://api.eventstore.cloud", Err: context.Canceled}},
+		{name: "deadline exceeded", err: &url.Error{Op: "Get", URL: "https://api.eventstore.cloud", Err: context.DeadlineExceeded}},
+		{name: "DNS failure", err: &net.DNSError{Err: "no such host", Name: "api.eventstore.cloud", IsNotFound: true}},
+		{name: "TLS failure", err: errors.New("tls: failed to verify certificate")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, method := range idempotent {
+				if retried := shouldRetry(method, tt.resp, tt.err); retried != tt.idempotent {
+					t.Errorf("%s: expected retry %t, got %t", method, tt.idempotent, retried)
+				}
+			}
+			for _, method := range other {
+				if retried := shouldRetry(method, tt.resp, tt.err); retried != tt.other {
+					t.Errorf("%s: expected retry %t, got %t", method, tt.other, retried)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "0", wait: 0, ok: true},
+		{value: "120", wait: 2 * time.Minute, ok: true},
+		{value: "-1", ok: false},
+		{value: "1.5", ok: false},
+		{value: "soon", ok: false},
+		{value: "Wed, 32 Oct 2015 07:28:00 GMT", ok: false},
+		{value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), wait: 0, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value)
+			if ok != tt.ok || wait != tt.wait {
+				t.Errorf("expected %s, %t, got %s, %t", tt.wait, tt.ok, wait, ok)
+			}
+		})
+	}
+
+	t.Run("HTTP date", func(t *testing.T) {
+		wait, ok := parseRetryAfter(time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat))
+		// The date has a precision of a second
+		if !ok || wait < 88*time.Second || wait > 90*time.Second {
+			t.Errorf("expected about 90s, got %s, %t", wait, ok)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	c := &Client{retryWaitMin: time.Second, retryWaitMax: 30 * time.Second}
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {value}}}
+	}
+
+	if wait := c.backoff(1, retryAfter("5")); wait != 5*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %s", wait)
+	}
+	if wait := c.backoff(1, retryAfter("3600")); wait != 30*time.Second {
+		t.Errorf("expected Retry-After to be capped at the maximum wait, got %s", wait)
+	}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		limit := min(500*time.Millisecond+time.Second<<(attempt-1), 30*time.Second)
+		for i := 0; i < 1000; i++ {
+			if wait := c.backoff(attempt, retryAfter("soon")); wait < 500*time.Millisecond || wait > limit {
+				t.Fatalf("attempt %d: expected a wait between 500ms and %s, got %s", attempt, limit, wait)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package client_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "identity provider", path: "/oauth/token"},
+		{name: "API", path: "/resources/v1/organizations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := clienttest.NewServer(nil)
+			t.Cleanup(server.Close)
+			server.InjectFault(clienttest.Fault{PathPrefix: tt.path, Delay: time.Minute})
+
+			config := server.ClientConfig()
+			config.RequestTimeout = 50 * time.Millisecond
+			config.RetryMaxAttempts = 1
+			c, err := client.New(config)
+			if err != nil {
+				t.Fatalf("creating client: %s", err)
+			}
+
+			start := time.Now()
+			_, err = c.OrganizationList(context.Background())
+			if err == nil || !strings.Contains(err.Error(), "Client.Timeout exceeded") {
+				t.Errorf("expected the request to time out, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("expected the timeout to interrupt the request, took %s", elapsed)
+			}
+		})
+	}
+}
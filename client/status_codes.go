@@ -2,15 +2,14 @@ package client
 
 import (
 	"io"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
-func translateStatusCode(status int, activity string, body io.Reader) diag.Diagnostics {
-	problemDetails, err := newProblemDetailsFromReader(body)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+func translateStatusCode(status int, activity string, body io.Reader) error {
+	data, _ := io.ReadAll(body)
 
-	return diag.Errorf("error %s: %s", activity, problemDetails.Error())
+	return &APIError{
+		StatusCode:     status,
+		Activity:       activity,
+		ProblemDetails: newProblemDetails(status, data),
+	}
 }
@@ -6,4 +6,13 @@ type tokenData struct {
 	Scope        string      `json:"scope"`
 	ExpiresIn    int         `json:"expires_in"`
 	TokenType    string      `json:"token_type"`
+
+	// Client the refresh token was issued to
+	ClientID string `json:"client_id,omitempty"`
+	// Hash of the configured refresh token from which RefreshToken was
+	// rotated, empty when it was obtained with the login command
+	RefreshTokenOrigin string `json:"refresh_token_origin,omitempty"`
+	// Hash of the OIDC token exchanged for AccessToken, empty unless it was
+	// obtained for workload identity
+	SubjectTokenHash string `json:"subject_token_hash,omitempty"`
 }
@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestTokenExchange(t *testing.T) {
+	const oidcToken = "workload-oidc-token"
+
+	tokenFile := filepath.Join(t.TempDir(), "oidc-token")
+	if err := os.WriteFile(tokenFile, []byte(oidcToken+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		configure func(*client.Config)
+	}{
+		{
+			name:      "token",
+			configure: func(config *client.Config) { config.OIDCToken = oidcToken },
+		},
+		{
+			name:      "token file",
+			configure: func(config *client.Config) { config.OIDCTokenFile = tokenFile },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := clienttest.NewServer(&clienttest.Config{SubjectTokens: []string{oidcToken}})
+			t.Cleanup(server.Close)
+
+			config := server.ClientConfig()
+			config.RefreshToken = ""
+			tt.configure(config)
+			c, err := client.New(config)
+			if err != nil {
+				t.Fatalf("creating client: %s", err)
+			}
+
+			if _, err := c.OrganizationList(ctx); err != nil {
+				t.Fatalf("calling the API: %s", err)
+			}
+
+			if issued := tokenRequests(server); issued != 1 {
+				t.Errorf("expected a single token exchange, got %d token requests", issued)
+			}
+			for _, request := range server.Requests() {
+				if request.Path != "/oauth/token" && !strings.HasPrefix(request.Path, "/.well-known/") && request.Subject != "workload|clienttest" {
+					t.Errorf("expected %s %s to use the exchanged token, got subject %q", request.Method, request.Path, request.Subject)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenExchangeRejected(t *testing.T) {
+	server := clienttest.NewServer(&clienttest.Config{SubjectTokens: []string{"workload-oidc-token"}})
+	t.Cleanup(server.Close)
+
+	config := server.ClientConfig()
+	config.RefreshToken = ""
+	config.OIDCToken = "expired-oidc-token"
+	c, err := client.New(config)
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	_, err = c.OrganizationList(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant: unknown or invalid subject token") {
+		t.Errorf("expected the identity provider's error, got %v", err)
+	}
+}
+
+// Each CI job brings a new OIDC token, which must be exchanged again without
+// leaving another entry in the token store
+func TestTokenExchangeNewSubjectToken(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(nil)
+	t.Cleanup(server.Close)
+	tokenStore := t.TempDir()
+
+	exchange := func(oidcToken string) {
+		t.Helper()
+
+		config := server.ClientConfig()
+		config.RefreshToken = ""
+		config.OIDCToken = oidcToken
+		config.TokenStoreType = client.TokenStoreFile
+		config.TokenStore = tokenStore
+		c, err := client.New(config)
+		if err != nil {
+			t.Fatalf("creating client: %s", err)
+		}
+		if err := c.TokenRefresh(ctx, false); err != nil {
+			t.Fatalf("obtaining access token: %s", err)
+		}
+	}
+
+	exchange("first-job-oidc-token")
+	exchange("first-job-oidc-token")
+	if issued := tokenRequests(server); issued != 1 {
+		t.Errorf("expected the exchanged token to be reused, got %d token requests", issued)
+	}
+
+	exchange("second-job-oidc-token")
+	if issued := tokenRequests(server); issued != 2 {
+		t.Errorf("expected a new subject token to be exchanged, got %d token requests", issued)
+	}
+
+	entries, err := os.ReadDir(tokenStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokens []string
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".lock") {
+			tokens = append(tokens, entry.Name())
+		}
+	}
+	if len(tokens) != 1 {
+		t.Errorf("expected a single cached token, got %v", tokens)
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+)
+
+// refresh exchanges a refresh token for a new access token. Identity providers
+// rotating refresh tokens invalidate the one used, so the latest one is kept
+// in the store and preferred over the configured token. Should the stored
+// token be rejected, e.g. because the store was copied from elsewhere, the
+// configured token is tried instead.
+func (c *Client) refresh(ctx context.Context, cached *tokenData) (*tokenData, error) {
+	candidates := []string{}
+	if c.ownsRefreshToken(cached) {
+		candidates = append(candidates, cached.RefreshToken)
+	}
+	if c.refreshToken != "" && (len(candidates) == 0 || candidates[0] != c.refreshToken) {
+		candidates = append(candidates, c.refreshToken)
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("the stored refresh token was issued to another client, set a refresh token or run the login command again")
+	}
+
+	var err error
+	for i, refreshToken := range candidates {
+		var result *tokenData
+		result, err = c.refreshWith(ctx, refreshToken)
+		if err == nil {
+			return result, nil
+		}
+
+		var oauthErr *oauthError
+		if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_grant" {
+			return nil, err
+		}
+		if i < len(candidates)-1 {
+			logWarn(ctx, "Stored refresh token was rejected, falling back to the configured token", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	return nil, err
+}
+
+func (c *Client) refreshWith(ctx context.Context, refreshToken string) (*tokenData, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", c.clientID)
+	form.Set("refresh_token", refreshToken)
+
+	result, err := c.postTokenForm(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	// The identity provider only returns a refresh token when rotating it, so
+	// keep the one used around for the next refresh
+	if result.RefreshToken == "" {
+		result.RefreshToken = refreshToken
+	}
+	result.ClientID = c.clientID
+	result.RefreshTokenOrigin = c.refreshTokenOrigin()
+
+	return result, nil
+}
+
+// ownsRefreshToken reports whether the refresh token in the store may be used
+// by this client: it must have been issued to the same OAuth client, and
+// descend from the configured refresh token so that configuring a new token
+// takes effect even while the old chain is still valid. Tokens stored by
+// earlier versions carry neither, and are only trusted when no refresh token
+// is configured.
+func (c *Client) ownsRefreshToken(cached *tokenData) bool {
+	if cached == nil || cached.RefreshToken == "" {
+		return false
+	}
+
+	if cached.ClientID == "" {
+		return c.refreshToken == ""
+	}
+
+	return cached.ClientID == c.clientID && cached.RefreshTokenOrigin == c.refreshTokenOrigin()
+}
+
+// refreshTokenOrigin identifies the configured refresh token without storing
+// it. It is empty when signing in with the login command.
+func (c *Client) refreshTokenOrigin() string {
+	if c.refreshToken == "" {
+		return ""
+	}
+
+	hash := sha256.Sum256([]byte(c.refreshToken))
+	return hex.EncodeToString(hash[:16])
+}
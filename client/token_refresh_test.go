@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// fakeIDP issues refresh tokens which are only valid once, like an identity
+// provider with refresh token rotation enabled.
+type fakeIDP struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// Refresh tokens which are currently valid
+	valid map[string]bool
+	// Refresh tokens presented, in order
+	presented []string
+	issued    int
+}
+
+func newFakeIDP(t *testing.T, refreshTokens ...string) *fakeIDP {
+	idp := &fakeIDP{valid: map[string]bool{}}
+	for _, token := range refreshTokens {
+		idp.valid[token] = true
+	}
+
+	idp.Server = httptest.NewServer(http.HandlerFunc(idp.handleToken))
+	t.Cleanup(idp.Close)
+
+	return idp
+}
+
+func (idp *fakeIDP) handleToken(w http.ResponseWriter, r *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "refresh_token" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"unsupported_grant_type"}`))
+		return
+	}
+
+	refreshToken := r.PostForm.Get("refresh_token")
+	idp.presented = append(idp.presented, refreshToken)
+
+	if !idp.valid[refreshToken] {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Unknown or invalid refresh token."}`))
+		return
+	}
+
+	delete(idp.valid, refreshToken)
+	idp.issued++
+	rotated := fmt.Sprintf("rotated-%d", idp.issued)
+	idp.valid[rotated] = true
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":  fmt.Sprintf("access-%d", idp.issued),
+		"refresh_token": rotated,
+		"expires_in":    86400,
+		"token_type":    "Bearer",
+	})
+}
+
+func (idp *fakeIDP) revoke(refreshToken string) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	delete(idp.valid, refreshToken)
+}
+
+func (idp *fakeIDP) grant(refreshToken string) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.valid[refreshToken] = true
+}
+
+func (idp *fakeIDP) presentedTokens() []string {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	return append([]string{}, idp.presented...)
+}
+
+func newTestClient(t *testing.T, idp *fakeIDP, tokenStore, clientID, refreshToken string) *Client {
+	t.Helper()
+
+	c, err := New(&Config{
+		URL:                 "https://api.example.com",
+		IdentityProviderURL: idp.URL,
+		ClientID:            clientID,
+		TokenStore:          tokenStore,
+		RefreshToken:        refreshToken,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	return c
+}
+
+func assertPresented(t *testing.T, idp *fakeIDP, expected ...string) {
+	t.Helper()
+
+	presented := idp.presentedTokens()
+	if fmt.Sprint(presented) != fmt.Sprint(expected) {
+		t.Fatalf("expected refresh tokens %v to be presented, got %v", expected, presented)
+	}
+}
+
+func TestRefreshUsesRotatedToken(t *testing.T) {
+	idp := newFakeIDP(t, "configured")
+	c := newTestClient(t, idp, t.TempDir(), "client", "configured")
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.accessToken(context.Background(), true); err != nil {
+			t.Fatalf("refresh %d: %v", i+1, err)
+		}
+	}
+
+	assertPresented(t, idp, "configured", "rotated-1", "rotated-2")
+
+	stored, err := c.tokenStore.get(c.audience)
+	if err != nil {
+		t.Fatalf("reading store: %v", err)
+	}
+	if stored.RefreshToken != "rotated-3" {
+		t.Fatalf("expected the latest refresh token to be stored, got %q", stored.RefreshToken)
+	}
+}
+
+func TestRefreshRotatedTokenSurvivesNewClient(t *testing.T) {
+	idp := newFakeIDP(t, "configured")
+	store := t.TempDir()
+
+	if _, err := newTestClient(t, idp, store, "client", "configured").accessToken(context.Background(), true); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+
+	// A later terraform run starts from the same configuration
+	if _, err := newTestClient(t, idp, store, "client", "configured").accessToken(context.Background(), true); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+
+	assertPresented(t, idp, "configured", "rotated-1")
+}
+
+func TestRefreshFallsBackToConfiguredToken(t *testing.T) {
+	idp := newFakeIDP(t, "configured")
+	c := newTestClient(t, idp, t.TempDir(), "client", "configured")
+
+	if _, err := c.accessToken(context.Background(), true); err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+
+	idp.revoke("rotated-1")
+	idp.grant("configured")
+
+	result, err := c.accessToken(context.Background(), true)
+	if err != nil {
+		t.Fatalf("refresh after revocation: %v", err)
+	}
+	if result.AccessToken != "access-2" {
+		t.Fatalf("expected a new access token, got %q", result.AccessToken)
+	}
+
+	assertPresented(t, idp, "configured", "rotated-1", "configured")
+}
+
+func TestRefreshFailsWhenAllTokensRejected(t *testing.T) {
+	idp := newFakeIDP(t, "configured")
+	c := newTestClient(t, idp, t.TempDir(), "client", "configured")
+
+	if _, err := c.accessToken(context.Background(), true); err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+
+	idp.revoke("rotated-1")
+
+	_, err := c.accessToken(context.Background(), true)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var oauthErr *oauthError
+	if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_grant" {
+		t.Fatalf("expected an invalid_grant error, got %v", err)
+	}
+
+	assertPresented(t, idp, "configured", "rotated-1", "configured")
+}
+
+func TestRefreshIgnoresTokensFromAnotherClient(t *testing.T) {
+	idp := newFakeIDP(t, "configured", "other")
+	store := t.TempDir()
+
+	if _, err := newTestClient(t, idp, store, "client", "configured").accessToken(context.Background(), true); err != nil {
+		t.Fatalf("first client: %v", err)
+	}
+
+	if _, err := newTestClient(t, idp, store, "other-client", "other").accessToken(context.Background(), true); err != nil {
+		t.Fatalf("second client: %v", err)
+	}
+
+	assertPresented(t, idp, "configured", "other")
+}
+
+func TestRefreshPrefersNewlyConfiguredToken(t *testing.T) {
+	idp := newFakeIDP(t, "configured", "replacement")
+	store := t.TempDir()
+
+	if _, err := newTestClient(t, idp, store, "client", "configured").accessToken(context.Background(), true); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+
+	if _, err := newTestClient(t, idp, store, "client", "replacement").accessToken(context.Background(), true); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+
+	assertPresented(t, idp, "configured", "replacement")
+}
+
+func TestRefreshUsesTokenStoredByLogin(t *testing.T) {
+	idp := newFakeIDP(t, "from-login")
+	c := newTestClient(t, idp, t.TempDir(), "client", "")
+
+	err := c.tokenStore.put(c.audience, tokenData{RefreshToken: "from-login", ClientID: "client"})
+	if err != nil {
+		t.Fatalf("seeding store: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.accessToken(context.Background(), true); err != nil {
+			t.Fatalf("refresh %d: %v", i+1, err)
+		}
+	}
+
+	assertPresented(t, idp, "from-login", "rotated-1")
+}
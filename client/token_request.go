@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Audience requested for machine identities, and accepted on access tokens
+const apiAudience = "https://api.eventstore.cloud"
+
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// requestToken obtains a new access token from the identity provider using
+// whichever grant the client has been configured for. cached is the token
+// currently in the store, if any.
+func (c *Client) requestToken(ctx context.Context, cached *tokenData) (*tokenData, error) {
+	form := url.Values{}
+	form.Set("client_id", c.clientID)
+
+	subjectToken, err := c.subjectToken()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case subjectToken != "":
+		form.Set("grant_type", grantTypeTokenExchange)
+		form.Set("subject_token", subjectToken)
+		form.Set("subject_token_type", tokenTypeJWT)
+		form.Set("requested_token_type", tokenTypeAccessToken)
+		form.Set("audience", apiAudience)
+		if c.clientSecret != "" {
+			form.Set("client_secret", c.clientSecret)
+		}
+	case c.clientSecret != "":
+		form.Set("grant_type", "client_credentials")
+		form.Set("client_secret", c.clientSecret)
+		form.Set("audience", apiAudience)
+	case c.refreshToken != "" || (cached != nil && cached.RefreshToken != ""):
+		return c.refresh(ctx, cached)
+	default:
+		return nil, fmt.Errorf("no credentials configured: set a refresh token, or a client ID and client secret, or run the login command")
+	}
+
+	result, err := c.postTokenForm(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	result.SubjectTokenHash = subjectTokenHash(subjectToken)
+
+	return result, nil
+}
+
+// oauthError is the error response defined by RFC 6749 section 5.2
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *oauthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func newOAuthErrorFromReader(r io.Reader) *oauthError {
+	data, _ := io.ReadAll(r)
+
+	result := oauthError{}
+	if err := json.Unmarshal(data, &result); err != nil || result.Code == "" {
+		return &oauthError{Code: "invalid_response", Description: summarizeBody(data)}
+	}
+	return &result
+}
+
+func (c *Client) postTokenForm(ctx context.Context, form url.Values) (*tokenData, error) {
+	idpURL := *c.idpURL
+	idpURL.Path = "/oauth/token"
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, idpURL.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error constructing request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting access token: %w", err)
+	}
+	defer closeIgnoreError(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error %d requesting access token: %w", resp.StatusCode, newOAuthErrorFromReader(resp.Body))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	result := tokenData{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("error parsing IDP response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// subjectToken returns the external OIDC token to exchange, or an empty string
+// when workload identity isn't configured.
+func (c *Client) subjectToken() (string, error) {
+	if c.oidcTokenFile == "" {
+		return c.oidcToken, nil
+	}
+
+	contents, err := os.ReadFile(c.oidcTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("error reading OIDC token file %q: %w", c.oidcTokenFile, err)
+	}
+
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		return "", fmt.Errorf("OIDC token file %q is empty", c.oidcTokenFile)
+	}
+
+	return token, nil
+}
+
+// tokenKey identifies the cached access token for the configured credentials.
+// Tokens obtained by a refresh token are shared with the Event Store Cloud CLI.
+// Those obtained for a machine identity are kept per client, with tokens
+// exchanged for workload identity recording which subject token they were
+// exchanged for, so that a new one is exchanged when the subject changes.
+func (c *Client) tokenKey() string {
+	if c.oidcToken != "" || c.oidcTokenFile != "" || c.clientSecret != "" {
+		return c.audience + "." + c.clientID
+	}
+
+	return c.audience
+}
+
+// subjectTokenHash identifies subjectToken without storing it, and is empty
+// when workload identity isn't configured
+func subjectTokenHash(subjectToken string) string {
+	if subjectToken == "" {
+		return ""
+	}
+
+	hash := sha256.Sum256([]byte(subjectToken))
+	return hex.EncodeToString(hash[:16])
+}
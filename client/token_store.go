@@ -2,18 +2,41 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
 )
 
+// TokenStore persists tokens between runs of the provider. Keys are plain
+// file names identifying the audience and credentials a token is for.
+type TokenStore interface {
+	// Read returns the data stored under key, or an error wrapping
+	// fs.ErrNotExist when there is none
+	Read(key string) ([]byte, error)
+	// Write replaces the data stored under key
+	Write(key string, data []byte) error
+	// Delete removes the data stored under key, if there is any
+	Delete(key string) error
+	// Lock takes an exclusive lock on key, shared with every process using
+	// the same store, and returns the function releasing it
+	Lock(key string) (func(), error)
+}
+
+// Token store types accepted in Config.TokenStoreType
+const (
+	TokenStoreFile      = "file"
+	TokenStoreEncrypted = "encrypted"
+	TokenStoreMemory    = "memory"
+)
+
+// tokenStore serializes tokens in and out of a TokenStore
 type tokenStore struct {
-	path string
+	backend TokenStore
 }
 
 // Check if a token exists within the store
 func (t *tokenStore) exists(audience string) bool {
-	if _, err := os.Stat(t.filePath(audience)); os.IsNotExist(err) {
+	if _, err := t.backend.Read(audience); errors.Is(err, fs.ErrNotExist) {
 		return false
 	}
 
@@ -22,18 +45,16 @@ func (t *tokenStore) exists(audience string) bool {
 
 // Get a token for audience from the store
 func (t *tokenStore) get(audience string) (*tokenData, error) {
-	tokenPath := t.filePath(audience)
-
-	bytes, err := os.ReadFile(tokenPath)
+	bytes, err := t.backend.Read(audience)
 	if err != nil {
-		return nil, fmt.Errorf("error reading token %q: %w", tokenPath, err)
+		return nil, err
 	}
 
 	tokenData := &tokenData{}
 
 	err = json.Unmarshal(bytes, &tokenData)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding json token %q: %w", tokenPath, err)
+		return nil, fmt.Errorf("error decoding json token %q: %w", audience, err)
 	}
 
 	return tokenData, nil
@@ -41,22 +62,19 @@ func (t *tokenStore) get(audience string) (*tokenData, error) {
 
 // Put a token from audience into the store
 func (t *tokenStore) put(audience string, token tokenData) error {
-	tokenPath := t.filePath(audience)
-
 	bytes, err := json.Marshal(token)
 	if err != nil {
 		return fmt.Errorf("error serializing token: %w", err)
 	}
 
-	err = os.WriteFile(tokenPath, bytes, 0o600)
-	if err != nil {
-		return fmt.Errorf("error writing token to store %q: %w", tokenPath, err)
-	}
+	return t.backend.Write(audience, bytes)
+}
 
-	return nil
+// Remove the token for audience from the store
+func (t *tokenStore) delete(audience string) error {
+	return t.backend.Delete(audience)
 }
 
-// Return token filepath for audience
-func (t *tokenStore) filePath(audience string) string {
-	return filepath.Join(t.path, audience)
+func (t *tokenStore) lock(audience string) (func(), error) {
+	return t.backend.Lock(audience)
 }
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Prefixes every encrypted token, so the format can evolve
+const encryptedTokenVersion byte = 1
+
+// Appended to the keys of encrypted tokens, so that they don't clash with the
+// plaintext tokens the Event Store Cloud CLI keeps in the same directory
+const encryptedTokenSuffix = ".enc"
+
+// EncryptedTokenStore encrypts tokens with AES-256-GCM before handing them to
+// another store, under their key followed by ".enc". The name of each token
+// is authenticated along with it, so encrypted files cannot be swapped around.
+type EncryptedTokenStore struct {
+	backend TokenStore
+	aead    cipher.AEAD
+}
+
+// NewEncryptedTokenStore wraps backend with a 32-byte key
+func NewEncryptedTokenStore(backend TokenStore, key []byte) (*EncryptedTokenStore, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("token store key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token store cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token store cipher: %w", err)
+	}
+
+	return &EncryptedTokenStore{backend: backend, aead: aead}, nil
+}
+
+func (t *EncryptedTokenStore) Read(key string) ([]byte, error) {
+	data, err := t.backend.Read(key + encryptedTokenSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := t.aead.NonceSize()
+	if len(data) < 1+nonceSize || data[0] != encryptedTokenVersion {
+		return nil, fmt.Errorf("token %q is not encrypted, remove it from the token store", key+encryptedTokenSuffix)
+	}
+
+	nonce, ciphertext := data[1:1+nonceSize], data[1+nonceSize:]
+	plaintext, err := t.aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt token %q, it may have been written with another key: %w", key, err)
+	}
+
+	return plaintext, nil
+}
+
+func (t *EncryptedTokenStore) Write(key string, data []byte) error {
+	nonce := make([]byte, t.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	sealed := append([]byte{encryptedTokenVersion}, nonce...)
+	sealed = t.aead.Seal(sealed, nonce, data, []byte(key))
+
+	return t.backend.Write(key+encryptedTokenSuffix, sealed)
+}
+
+func (t *EncryptedTokenStore) Delete(key string) error {
+	return t.backend.Delete(key + encryptedTokenSuffix)
+}
+
+func (t *EncryptedTokenStore) Lock(key string) (func(), error) {
+	return t.backend.Lock(key + encryptedTokenSuffix)
+}
+
+// readTokenStoreKey decodes a base64 key given directly or in a file
+func readTokenStoreKey(key, keyFile string) ([]byte, error) {
+	switch {
+	case key != "" && keyFile != "":
+		return nil, errors.New("only one of token store key and token store key file may be set")
+	case keyFile != "":
+		contents, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading token store key file %q: %w", keyFile, err)
+		}
+		key = string(contents)
+	case key == "":
+		return nil, errors.New("an encrypted token store requires a key or key file")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("token store key must be base64 encoded: %w", err)
+	}
+
+	return decoded, nil
+}
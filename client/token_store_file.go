@@ -0,0 +1,96 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileTokenStore keeps tokens as plaintext files in a directory, in the same
+// format as the Event Store Cloud CLI so that both can share it.
+type FileTokenStore struct {
+	path string
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (t *FileTokenStore) Read(key string) ([]byte, error) {
+	tokenPath := t.filePath(key)
+
+	bytes, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading token %q: %w", tokenPath, err)
+	}
+
+	return bytes, nil
+}
+
+func (t *FileTokenStore) Write(key string, data []byte) error {
+	tokenPath := t.filePath(key)
+
+	// Write to a temporary file and rename it over the token, so readers in
+	// other processes never see a partially written file
+	tmp, err := os.CreateTemp(t.path, "."+key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error writing token to store %q: %w", tokenPath, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing token to store %q: %w", tokenPath, err)
+	}
+
+	if err := os.Rename(tmp.Name(), tokenPath); err != nil {
+		return fmt.Errorf("error writing token to store %q: %w", tokenPath, err)
+	}
+
+	return nil
+}
+
+func (t *FileTokenStore) Delete(key string) error {
+	tokenPath := t.filePath(key)
+
+	if err := os.Remove(tokenPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error removing token %q: %w", tokenPath, err)
+	}
+
+	return nil
+}
+
+// Lock takes an advisory lock on a file next to the token
+func (t *FileTokenStore) Lock(key string) (func(), error) {
+	lockPath := t.filePath(key) + ".lock"
+
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening token lock %q: %w", lockPath, err)
+	}
+
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("error locking token %q: %w", lockPath, err)
+	}
+
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
+
+// Return token filepath for key
+func (t *FileTokenStore) filePath(key string) string {
+	return filepath.Join(t.path, key)
+}
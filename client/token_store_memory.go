@@ -0,0 +1,64 @@
+package client
+
+import (
+	"fmt"
+	"io/fs"
+	"sync"
+)
+
+// MemoryTokenStore keeps tokens for the lifetime of the process only, for
+// ephemeral CI runners where nothing should be left on disk. Every provider
+// run obtains a new access token.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string][]byte
+	locks  map[string]*sync.Mutex
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: map[string][]byte{},
+		locks:  map[string]*sync.Mutex{},
+	}
+}
+
+func (t *MemoryTokenStore) Read(key string) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	data, ok := t.tokens[key]
+	if !ok {
+		return nil, fmt.Errorf("error reading token %q: %w", key, fs.ErrNotExist)
+	}
+
+	return append([]byte{}, data...), nil
+}
+
+func (t *MemoryTokenStore) Write(key string, data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tokens[key] = append([]byte{}, data...)
+	return nil
+}
+
+func (t *MemoryTokenStore) Delete(key string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tokens, key)
+	return nil
+}
+
+func (t *MemoryTokenStore) Lock(key string) (func(), error) {
+	t.mu.Lock()
+	lock, ok := t.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		t.locks[key] = lock
+	}
+	t.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock, nil
+}
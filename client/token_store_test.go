@@ -0,0 +1,191 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestEncryptedStore(t *testing.T, backend TokenStore, key byte) *EncryptedTokenStore {
+	t.Helper()
+
+	store, err := NewEncryptedTokenStore(backend, bytes.Repeat([]byte{key}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestEncryptedTokenStore(t *testing.T) {
+	const key = "https:__api.eventstore.cloud"
+	token := []byte(`{"access_token":"secret-access-token"}`)
+
+	t.Run("round trip", func(t *testing.T) {
+		dir := t.TempDir()
+		store := newTestEncryptedStore(t, NewFileTokenStore(dir), 1)
+
+		if _, err := store.Read(key); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected no token, got %v", err)
+		}
+		if err := store.Write(key, token); err != nil {
+			t.Fatal(err)
+		}
+
+		onDisk, err := os.ReadFile(filepath.Join(dir, key+".enc"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(onDisk, []byte("secret-access-token")) {
+			t.Errorf("token was written in plaintext: %s", onDisk)
+		}
+
+		read, err := store.Read(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(read, token) {
+			t.Errorf("expected %s, got %s", token, read)
+		}
+
+		if err := store.Delete(key); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.Read(key); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected the token to be gone, got %v", err)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		backend := NewMemoryTokenStore()
+		if err := newTestEncryptedStore(t, backend, 1).Write(key, token); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := newTestEncryptedStore(t, backend, 2).Read(key)
+		if err == nil || !strings.Contains(err.Error(), "cannot decrypt token") {
+			t.Errorf("expected a decryption error, got %v", err)
+		}
+	})
+
+	t.Run("swapped tokens", func(t *testing.T) {
+		backend := NewMemoryTokenStore()
+		store := newTestEncryptedStore(t, backend, 1)
+		if err := store.Write("other", token); err != nil {
+			t.Fatal(err)
+		}
+		sealed, _ := backend.Read("other.enc")
+		if err := backend.Write(key+".enc", sealed); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := store.Read(key); err == nil {
+			t.Error("expected a token stored under another key to be rejected")
+		}
+	})
+
+	t.Run("plaintext token present", func(t *testing.T) {
+		// As left by the Event Store Cloud CLI, or the file token store
+		dir := t.TempDir()
+		plaintext := NewFileTokenStore(dir)
+		if err := plaintext.Write(key, []byte(`{"access_token":"cli-token"}`)); err != nil {
+			t.Fatal(err)
+		}
+		store := newTestEncryptedStore(t, NewFileTokenStore(dir), 1)
+
+		if _, err := store.Read(key); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected the plaintext token to be ignored, got %v", err)
+		}
+		if err := store.Write(key, token); err != nil {
+			t.Fatal(err)
+		}
+		if read, err := store.Read(key); err != nil || !bytes.Equal(read, token) {
+			t.Errorf("expected %s, got %s (%v)", token, read, err)
+		}
+		if read, err := plaintext.Read(key); err != nil || string(read) != `{"access_token":"cli-token"}` {
+			t.Errorf("expected the plaintext token to be left alone, got %s (%v)", read, err)
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		if _, err := NewEncryptedTokenStore(NewMemoryTokenStore(), make([]byte, 16)); err == nil {
+			t.Error("expected a 16-byte key to be rejected")
+		}
+	})
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	store := NewMemoryTokenStore()
+
+	if _, err := store.Read("token"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected no token, got %v", err)
+	}
+
+	data := []byte("first")
+	if err := store.Write("token", data); err != nil {
+		t.Fatal(err)
+	}
+	// The store keeps its own copy
+	data[0] = 'F'
+	read, err := store.Read("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(read) != "first" {
+		t.Errorf("expected first, got %s", read)
+	}
+	read[0] = 'F'
+	if read, _ := store.Read("token"); string(read) != "first" {
+		t.Errorf("expected the stored token to be unchanged, got %s", read)
+	}
+
+	if err := store.Delete("token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Read("token"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the token to be gone, got %v", err)
+	}
+	if err := store.Delete("token"); err != nil {
+		t.Errorf("expected deleting a missing token to succeed, got %v", err)
+	}
+}
+
+func TestMemoryTokenStoreLock(t *testing.T) {
+	store := NewMemoryTokenStore()
+
+	unlock, err := store.Lock("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Other keys are not locked
+	unlockOther, err := store.Lock("other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlockOther()
+
+	locked := make(chan struct{})
+	go func() {
+		unlock, _ := store.Lock("token")
+		close(locked)
+		unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("expected the lock to be held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the lock to be released")
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// loginCommand signs the user in with the device authorization flow and
+// stores the resulting refresh token in the token store, so that neither
+// escctl nor the provider need a `token`
+type loginCommand struct{}
+
+var login = loginCommand{}
+
+func (loginCommand) run(ctx context.Context, s *session, name string, args []string) error {
+	flags := s.flagSet(name)
+	var identityProviderURL, clientID, tokenStore, tokenStoreType string
+	flags.StringVar(&identityProviderURL, "identity-provider-url", "", "URL of the identity provider")
+	flags.StringVar(&clientID, "client-id", "", "OAuth client to sign in with")
+	flags.StringVar(&tokenStore, "token-store", "", "directory in which to store tokens")
+	flags.StringVar(&tokenStoreType, "token-store-type", "", "one of file or encrypted")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return usageErrorf("%s takes no arguments", name)
+	}
+
+	config, err := s.clientConfig()
+	if err != nil {
+		return err
+	}
+	// Signing in stores a refresh token for the user, which machine
+	// credentials would be used instead of
+	config.RefreshToken = ""
+	config.ClientSecret = ""
+	config.OIDCToken = ""
+	config.OIDCTokenFile = ""
+	if identityProviderURL != "" {
+		config.IdentityProviderURL = identityProviderURL
+	}
+	if clientID != "" {
+		config.ClientID = clientID
+	}
+	if tokenStore != "" {
+		config.TokenStore = tokenStore
+	}
+	if tokenStoreType != "" {
+		config.TokenStoreType = tokenStoreType
+	}
+	if config.TokenStoreType == client.TokenStoreMemory {
+		return errors.New("logging in requires a token store which persists tokens")
+	}
+
+	c, err := s.newClient(config)
+	if err != nil {
+		return err
+	}
+
+	err = c.Login(ctx, func(authorization *client.DeviceAuthorization) {
+		fmt.Fprintf(s.stderr, "To sign in, open %s and enter the code %s\n", authorization.VerificationURI, authorization.UserCode)
+		if authorization.VerificationURIComplete != "" {
+			fmt.Fprintf(s.stderr, "or open %s directly.\n", authorization.VerificationURIComplete)
+		}
+		fmt.Fprintln(s.stderr, "Waiting for the login to be approved...")
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(s.stderr, "Logged in, tokens have been stored in %s\n", config.TokenStore)
+	return nil
+}
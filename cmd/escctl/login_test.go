@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+func TestLogin(t *testing.T) {
+	newTestServer(t, nil)
+	t.Setenv("ESC_TOKEN", "")
+	t.Setenv("ESC_TOKEN_STORE_TYPE", client.TokenStoreFile)
+	t.Setenv("ESC_TOKEN_STORE", t.TempDir())
+
+	code, _, stderr := runCommand("login")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stderr, "enter the code") || !strings.Contains(stderr, "Logged in") {
+		t.Errorf("expected to be prompted and logged in, got:\n%s", stderr)
+	}
+
+	// The token commands find the tokens stored by the login
+	code, stdout, stderr := runCommand("token", "inspect")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Refresh token:  cached") {
+		t.Errorf("expected the refresh token to be stored, got:\n%s", stdout)
+	}
+}
+
+func TestLoginRequiresPersistentTokenStore(t *testing.T) {
+	newTestServer(t, nil)
+
+	code, _, stderr := runCommand("login")
+	if code != 1 || !strings.Contains(stderr, "requires a token store which persists tokens") {
+		t.Errorf("expected the memory token store to be refused, got %d: %s", code, stderr)
+	}
+}
@@ -0,0 +1,281 @@
+// Command escctl lists and inspects Event Store Cloud resources from the shell,
+// and manages the access token cached for them.
+//
+// It is configured by the same ESC_* environment variables and profiles as the
+// Terraform provider, and shares its token store, so that signing in with its
+// login command works for both. Resources are created,
+// changed and deleted with Terraform; escctl only reads them, except for
+// creating scheduled backup jobs.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+	"github.com/EventStore/terraform-provider-eventstorecloud/esc"
+)
+
+var version = "dev"
+
+const usage = `Usage: escctl [flags] <command> <action> [flags] [id]
+
+Commands:
+  organizations list
+  projects      list | get <id>
+  networks      list | get <id>    -project <id>
+  peerings      list | get <id>    -project <id>
+  clusters      list | get <id>    -project <id>
+  acls          list | get <id>    -project <id>
+  jobs          list | get <id>    -project <id>
+                create             -project <id> -cluster <id> -schedule <cron>
+                                   -name <name> -backup-description <text>
+                                   -max-backup-count <count>
+  integrations  list | get <id>    -project <id>
+  token         inspect | refresh [-force] | clear
+  login         [-identity-provider-url <url>] [-client-id <id>]
+                [-token-store <dir>] [-token-store-type <type>]
+
+Flags, accepted before the command or after the action:
+  -profile name   profile from the config file, defaults to ESC_PROFILE
+  -org id         organization, defaults to ESC_ORG_ID, then the profile's,
+                  then the only organization accessible
+  -o format       output format, table (the default) or json
+
+Every other setting is read from the same ESC_* environment variables as the
+Terraform provider, e.g. ESC_TOKEN or ESC_URL.
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// command is a top level command, which parses the rest of the arguments
+type command interface {
+	run(ctx context.Context, s *session, name string, args []string) error
+}
+
+var commands = map[string]command{
+	"organizations": organizations,
+	"projects":      projects,
+	"networks":      networks,
+	"peerings":      peerings,
+	"clusters":      clusters,
+	"acls":          acls,
+	"jobs":          jobs,
+	"integrations":  integrations,
+	"token":         token,
+	"login":         login,
+}
+
+// run executes the command given by args and returns the exit code: 1 when
+// the command failed and 2 when it was used incorrectly
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	err := execute(ctx, args, stdout, stderr)
+
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		fmt.Fprint(stdout, usage)
+		return 0
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "Error: %s\n\n%s", err, usage)
+		return 2
+	}
+
+	fmt.Fprintf(stderr, "Error: %s\n", err)
+	return 1
+}
+
+func execute(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	s := &session{
+		options: options{
+			profileName: os.Getenv("ESC_PROFILE"),
+			output:      outputTable,
+		},
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	flags := s.flagSet("escctl")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return usageErrorf("a command is required")
+	}
+
+	name := flags.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		return usageErrorf("unknown command %q, expected one of %s", name, strings.Join(commandNames(), ", "))
+	}
+	return cmd.run(ctx, s, name, flags.Args()[1:])
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// usageError reports a command used incorrectly, as opposed to one which
+// failed
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usageErrorf(format string, a ...interface{}) error {
+	return usageError{message: fmt.Sprintf(format, a...)}
+}
+
+// options are the flags accepted by every command
+type options struct {
+	profileName    string
+	organizationID string
+	output         string
+}
+
+// session holds the state shared by the parts of a command. The client is
+// only created once a command needs it, so that usage errors are reported
+// without reading any configuration.
+type session struct {
+	options
+	stdout io.Writer
+	stderr io.Writer
+
+	profile *esc.Profile
+	config  *client.Config
+	c       *client.Client
+}
+
+// flagSet returns a flag set accepting the common options. Since they are
+// bound to the session, flags given before the command are kept unless given
+// again after the action.
+func (s *session) flagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&s.profileName, "profile", s.profileName, "profile from the config file")
+	flags.StringVar(&s.organizationID, "org", s.organizationID, "organization ID")
+	flags.StringVar(&s.output, "o", s.output, "output format, table or json")
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	err := flags.Parse(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return usageError{message: err.Error()}
+}
+
+func (s *session) loadProfile() (*esc.Profile, error) {
+	if s.profile == nil {
+		profile, err := esc.LoadProfile(s.profileName)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			profile = &esc.Profile{}
+		}
+		s.profile = profile
+	}
+	return s.profile, nil
+}
+
+// client returns a client configured like the provider
+func (s *session) client() (*client.Client, error) {
+	if s.c != nil {
+		return s.c, nil
+	}
+
+	config, err := s.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return s.newClient(config)
+}
+
+// clientConfig returns the configuration the provider would use, from the
+// environment and the profile
+func (s *session) clientConfig() (*client.Config, error) {
+	profile, err := s.loadProfile()
+	if err != nil {
+		return nil, err
+	}
+	config, err := esc.EnvConfig(profile)
+	if err != nil {
+		return nil, err
+	}
+	config.UserAgent = fmt.Sprintf("escctl/%s", version)
+	return config, nil
+}
+
+// newClient creates the client of the session from config
+func (s *session) newClient(config *client.Config) (*client.Client, error) {
+	if config.InsecureSkipVerify {
+		fmt.Fprintln(s.stderr, "Warning: TLS certificate verification is disabled, your tokens may be intercepted")
+	}
+
+	c, err := client.New(config)
+	if err != nil {
+		return nil, err
+	}
+	s.config = config
+	s.c = c
+	return c, nil
+}
+
+func (s *session) api() (*escapi.Client, error) {
+	c, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+	return c.API(), nil
+}
+
+// organization returns the organization to work in, which is that of the
+// -org flag, ESC_ORG_ID or the profile, and otherwise the only one accessible
+func (s *session) organization(ctx context.Context) (string, error) {
+	if s.organizationID != "" {
+		return s.organizationID, nil
+	}
+
+	profile, err := s.loadProfile()
+	if err != nil {
+		return "", err
+	}
+	if organizationID := esc.EnvOrganizationID(profile); organizationID != "" {
+		return organizationID, nil
+	}
+
+	c, err := s.client()
+	if err != nil {
+		return "", err
+	}
+	org, err := c.OrganizationDiscover(ctx, "-org (or ESC_ORG_ID)")
+	if err != nil {
+		return "", err
+	}
+	return org.OrganizationID, nil
+}
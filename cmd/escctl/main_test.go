@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// newTestServer starts a fake API and points the environment at it, the same
+// way users configure the provider
+func newTestServer(t *testing.T, config *clienttest.Config) *clienttest.Server {
+	t.Helper()
+
+	server := clienttest.NewServer(config)
+	t.Cleanup(server.Close)
+
+	t.Setenv("ESC_URL", server.URL)
+	t.Setenv("ESC_IDENTITY_PROVIDER_URL", server.URL)
+	t.Setenv("ESC_TOKEN", clienttest.RefreshToken)
+	t.Setenv("ESC_TOKEN_STORE_TYPE", client.TokenStoreMemory)
+	t.Setenv("ESC_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("ESC_PROFILE", "")
+	t.Setenv("ESC_ORG_ID", "")
+
+	return server
+}
+
+// runCommand runs escctl with args, returning its exit code and outputs
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t, nil)
+
+	c, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, err := c.ProjectCreate(ctx, &client.CreateProjectRequest{
+		OrganizationID: clienttest.DefaultOrganizationID,
+		Name:           "Example project",
+	})
+	if err != nil {
+		t.Fatalf("creating project: %s", err)
+	}
+	network, err := c.NetworkCreate(ctx, &client.CreateNetworkRequest{
+		OrganizationID:   clienttest.DefaultOrganizationID,
+		ProjectID:        project.ProjectID,
+		ResourceProvider: "aws",
+		CidrBlock:        "172.21.0.0/16",
+		Name:             "Example network",
+		Region:           "eu-west-1",
+	})
+	if err != nil {
+		t.Fatalf("creating network: %s", err)
+	}
+	acl, err := c.AclCreate(ctx, &client.CreateAclRequest{
+		OrganizationID: clienttest.DefaultOrganizationID,
+		ProjectID:      project.ProjectID,
+		Name:           "Example ACL",
+		CidrBlocks:     []client.AclCidrBlock{{Address: "192.0.2.0/24"}, {Address: "198.51.100.0/24"}},
+	})
+	if err != nil {
+		t.Fatalf("creating ACL: %s", err)
+	}
+	cluster, err := c.ManagedClusterCreate(ctx, &client.CreateManagedClusterRequest{
+		OrganizationID:  clienttest.DefaultOrganizationID,
+		ProjectID:       project.ProjectID,
+		NetworkId:       network.NetworkID,
+		Name:            "Example cluster",
+		Topology:        "single-node",
+		InstanceType:    "F1",
+		DiskSizeGB:      10,
+		DiskType:        "GP3",
+		DiskIops:        3000,
+		DiskThroughput:  125,
+		ServerVersion:   "24.10",
+		ProjectionLevel: "off",
+	})
+	if err != nil {
+		t.Fatalf("creating cluster: %s", err)
+	}
+
+	t.Run("table", func(t *testing.T) {
+		code, stdout, stderr := runCommand("acls", "list", "-project", project.ProjectID)
+		if code != 0 {
+			t.Fatalf("exit code %d: %s", code, stderr)
+		}
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected a header and a row, got:\n%s", stdout)
+		}
+		if fields := strings.Fields(lines[0]); !strings.HasPrefix(lines[0], "ID") || fields[len(fields)-1] != "STATUS" {
+			t.Errorf("unexpected header %q", lines[0])
+		}
+		if !strings.Contains(lines[1], acl.AclID) || !strings.Contains(lines[1], "192.0.2.0/24,198.51.100.0/24") {
+			t.Errorf("unexpected row %q", lines[1])
+		}
+	})
+
+	t.Run("json list", func(t *testing.T) {
+		code, stdout, stderr := runCommand("-o", "json", "networks", "list", "-project", project.ProjectID)
+		if code != 0 {
+			t.Fatalf("exit code %d: %s", code, stderr)
+		}
+
+		var networks []client.Network
+		if err := json.Unmarshal([]byte(stdout), &networks); err != nil {
+			t.Fatalf("decoding %q: %s", stdout, err)
+		}
+		if len(networks) != 1 || networks[0].NetworkID != network.NetworkID || networks[0].Name != "Example network" {
+			t.Errorf("unexpected networks %+v", networks)
+		}
+	})
+
+	t.Run("json get", func(t *testing.T) {
+		code, stdout, stderr := runCommand("projects", "get", "-o", "json", project.ProjectID)
+		if code != 0 {
+			t.Fatalf("exit code %d: %s", code, stderr)
+		}
+
+		var got client.Project
+		if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+			t.Fatalf("decoding %q: %s", stdout, err)
+		}
+		if got.ProjectID != project.ProjectID || got.Name != "Example project" {
+			t.Errorf("unexpected project %+v", got)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		code, stdout, stderr := runCommand("-o", "json", "integrations", "list", "-project", project.ProjectID)
+		if code != 0 {
+			t.Fatalf("exit code %d: %s", code, stderr)
+		}
+		if strings.TrimSpace(stdout) != "[]" {
+			t.Errorf("expected an empty array, got %q", stdout)
+		}
+	})
+
+	t.Run("create job", func(t *testing.T) {
+		code, stdout, stderr := runCommand("-o", "json", "jobs", "create", "-project", project.ProjectID,
+			"-cluster", cluster.ClusterID, "-schedule", "0 12 * * */2", "-name", "Nightly backup",
+			"-backup-description", "Nightly", "-max-backup-count", "3")
+		if code != 0 {
+			t.Fatalf("exit code %d: %s", code, stderr)
+		}
+
+		var created client.Job
+		if err := json.Unmarshal([]byte(stdout), &created); err != nil {
+			t.Fatalf("decoding %q: %s", stdout, err)
+		}
+		if created.Type != "ScheduledBackup" || created.Schedule != "0 12 * * */2" || created.Data["clusterId"] != cluster.ClusterID {
+			t.Errorf("unexpected job %+v", created)
+		}
+
+		job, err := c.GetJob(ctx, clienttest.DefaultOrganizationID, project.ProjectID, created.Id)
+		if err != nil {
+			t.Fatalf("getting job: %s", err)
+		}
+		if job.Job.Description != "Nightly backup" || job.Job.Data["maxBackupCount"] != float64(3) {
+			t.Errorf("unexpected job %+v", job.Job)
+		}
+	})
+
+	t.Run("create job for a missing cluster", func(t *testing.T) {
+		code, _, stderr := runCommand("jobs", "create", "-project", project.ProjectID,
+			"-cluster", "missing", "-schedule", "0 12 * * */2", "-name", "Nightly backup",
+			"-backup-description", "Nightly", "-max-backup-count", "3")
+		if code != 1 {
+			t.Errorf("expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stderr, "cluster not found") {
+			t.Errorf("expected the API's validation error, got %q", stderr)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		code, _, stderr := runCommand("peerings", "get", "-project", project.ProjectID, "missing")
+		if code != 1 {
+			t.Errorf("expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stderr, "missing not found") {
+			t.Errorf("expected a not found error, got %q", stderr)
+		}
+	})
+
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request.UserAgent, "escctl/") {
+			return
+		}
+	}
+	t.Error("no request was made with the escctl user agent")
+}
+
+func TestUsageErrors(t *testing.T) {
+	newTestServer(t, nil)
+
+	tests := []struct {
+		name    string
+		args    []string
+		message string
+	}{
+		{
+			name:    "no command",
+			args:    nil,
+			message: "a command is required",
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"volumes", "list"},
+			message: `unknown command "volumes"`,
+		},
+		{
+			name:    "unknown action",
+			args:    []string{"networks", "delete", "-project", "project", "network"},
+			message: `unknown action "delete", expected list or get`,
+		},
+		{
+			name:    "unknown job action",
+			args:    []string{"jobs", "delete", "-project", "project", "job"},
+			message: `unknown action "delete", expected list, get or create`,
+		},
+		{
+			name:    "resources which can't be created",
+			args:    []string{"networks", "create", "-project", "project"},
+			message: `unknown action "create", expected list or get`,
+		},
+		{
+			name:    "create without a cluster",
+			args:    []string{"jobs", "create", "-project", "project", "-name", "backup", "-schedule", "0 12 * * *", "-backup-description", "backup", "-max-backup-count", "1"},
+			message: "jobs create: -cluster is required",
+		},
+		{
+			name:    "create without backups to keep",
+			args:    []string{"jobs", "create", "-project", "project", "-cluster", "cluster", "-name", "backup", "-schedule", "0 12 * * *", "-backup-description", "backup"},
+			message: "-max-backup-count must be at least 1",
+		},
+		{
+			name:    "get without an ID",
+			args:    []string{"clusters", "get", "-project", "project"},
+			message: "clusters get takes the ID of a resource",
+		},
+		{
+			name:    "organizations can't be read individually",
+			args:    []string{"organizations", "get", "organization"},
+			message: `unknown action "get", expected list`,
+		},
+		{
+			name:    "missing project",
+			args:    []string{"jobs", "list"},
+			message: "-project is required",
+		},
+		{
+			name:    "login with arguments",
+			args:    []string{"login", "user"},
+			message: "login takes no arguments",
+		},
+		{
+			name:    "unknown output",
+			args:    []string{"-o", "yaml", "projects", "list"},
+			message: `unknown output format "yaml"`,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"projects", "list", "-verbose"},
+			message: "flag provided but not defined: -verbose",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCommand(tt.args...)
+			if code != 2 {
+				t.Errorf("expected exit code 2, got %d", code)
+			}
+			if !strings.Contains(stderr, tt.message) || !strings.Contains(stderr, "Usage: escctl") {
+				t.Errorf("expected %q and the usage, got:\n%s", tt.message, stderr)
+			}
+		})
+	}
+}
+
+func TestOrganization(t *testing.T) {
+	newTestServer(t, &clienttest.Config{
+		Organizations: []client.Organization{
+			{OrganizationID: "first", Name: "First"},
+			{OrganizationID: "second", Name: "Second"},
+		},
+	})
+
+	code, _, stderr := runCommand("projects", "list")
+	if code != 1 || !strings.Contains(stderr, "several organizations are accessible") || !strings.Contains(stderr, "second (Second)") {
+		t.Errorf("expected the organizations to choose from, got %d: %s", code, stderr)
+	}
+
+	code, stdout, stderr := runCommand("-org", "second", "projects", "list")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if strings.TrimSpace(stdout) != "ID   NAME   CREATED" {
+		t.Errorf("expected no projects, got:\n%s", stdout)
+	}
+
+	t.Setenv("ESC_ORG_ID", "first")
+	if code, _, stderr := runCommand("projects", "list"); code != 0 {
+		t.Errorf("expected ESC_ORG_ID to be used, got %d: %s", code, stderr)
+	}
+}
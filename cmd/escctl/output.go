@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// output writes results either as an aligned table for people, or as the
+// JSON returned by the API for scripts
+type output struct {
+	format string
+	w      io.Writer
+}
+
+func newOutput(format string, w io.Writer) (output, error) {
+	if format != outputTable && format != outputJSON {
+		return output{}, usageErrorf("unknown output format %q, expected %s or %s", format, outputTable, outputJSON)
+	}
+	return output{format: format, w: w}, nil
+}
+
+// writeFields writes value as JSON, or fields as a list of names and values
+func (o output) writeFields(value interface{}, fields [][2]string) error {
+	if o.format == outputJSON {
+		return o.write(value, nil, nil)
+	}
+
+	tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		fmt.Fprintf(tw, "%s:\t%s\n", field[0], field[1])
+	}
+	return tw.Flush()
+}
+
+// write writes value as JSON, or rows under the columns headers as a table.
+// Empty cells are shown as a dash so that columns can still be split on
+// whitespace.
+func (o output) write(value interface{}, columns []string, rows [][]string) error {
+	if o.format == outputJSON {
+		encoder := json.NewEncoder(o.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	tw := tabwriter.NewWriter(o.w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		for i, cell := range row {
+			if cell == "" {
+				row[i] = "-"
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
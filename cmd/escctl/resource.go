@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+// scope is where a kind of resource lives, and so which IDs are needed to
+// find one
+type scope int
+
+const (
+	scopeGlobal scope = iota
+	scopeOrganization
+	scopeProject
+)
+
+// location identifies the organization and project resources are listed in
+type location struct {
+	organizationID string
+	projectID      string
+}
+
+// resource is a command listing, getting and possibly creating one kind of
+// resource
+type resource[T any] struct {
+	scope scope
+	// Table headers, and the matching cells of a resource
+	columns []string
+	row     func(T) []string
+
+	list func(ctx context.Context, api *escapi.Client, at location) ([]T, error)
+	// Nil when resources can't be read individually
+	get func(ctx context.Context, api *escapi.Client, at location, id string) (*T, error)
+	// Nil when resources can't be created, otherwise binds the flags of the
+	// create action
+	create func(flags *flag.FlagSet) creator
+}
+
+// creator creates a resource from the flags of the create action
+type creator interface {
+	// validate returns a usage error when flags are missing or invalid
+	validate() error
+	// create returns the ID of the new resource
+	create(ctx context.Context, api *escapi.Client, at location) (string, error)
+}
+
+// actions lists the actions supported by the resource, for usage errors
+func (r *resource[T]) actions() string {
+	actions := []string{"list"}
+	if r.get != nil {
+		actions = append(actions, "get")
+	}
+	if r.create != nil {
+		actions = append(actions, "create")
+	}
+	if len(actions) == 1 {
+		return actions[0]
+	}
+	return strings.Join(actions[:len(actions)-1], ", ") + " or " + actions[len(actions)-1]
+}
+
+func (r *resource[T]) run(ctx context.Context, s *session, name string, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("%s: an action is required, expected %s", name, r.actions())
+	}
+	action := args[0]
+
+	flags := s.flagSet(name + " " + action)
+	var projectID string
+	if r.scope == scopeProject {
+		flags.StringVar(&projectID, "project", "", "project ID")
+	}
+	var create creator
+	if action == "create" && r.create != nil {
+		create = r.create(flags)
+	}
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+
+	switch {
+	case (action == "list" || action == "create") && flags.NArg() != 0:
+		return usageErrorf("%s %s takes no arguments", name, action)
+	case action == "get" && r.get != nil && flags.NArg() != 1:
+		return usageErrorf("%s get takes the ID of a resource", name)
+	case action != "list" && (action != "get" || r.get == nil) && create == nil:
+		return usageErrorf("%s: unknown action %q, expected %s", name, action, r.actions())
+	}
+	if r.scope == scopeProject && projectID == "" {
+		return usageErrorf("%s %s: -project is required", name, action)
+	}
+	if create != nil {
+		if err := create.validate(); err != nil {
+			return err
+		}
+	}
+	out, err := newOutput(s.output, s.stdout)
+	if err != nil {
+		return err
+	}
+
+	api, err := s.api()
+	if err != nil {
+		return err
+	}
+	at := location{projectID: projectID}
+	if r.scope != scopeGlobal {
+		if at.organizationID, err = s.organization(ctx); err != nil {
+			return err
+		}
+	}
+
+	id := flags.Arg(0)
+	switch action {
+	case "list":
+		items, err := r.list(ctx, api, at)
+		if err != nil {
+			return err
+		}
+		return writeItems(out, r.columns, r.row, items)
+	case "create":
+		if id, err = create.create(ctx, api, at); err != nil {
+			return err
+		}
+	}
+
+	item, err := r.get(ctx, api, at, id)
+	if err != nil {
+		return err
+	}
+	return writeItem(out, r.columns, r.row, *item)
+}
+
+// writeItems writes a list of resources, as an array in JSON
+func writeItems[T any](out output, columns []string, row func(T) []string, items []T) error {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, row(item))
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return out.write(items, columns, rows)
+}
+
+// writeItem writes a single resource, as an object in JSON
+func writeItem[T any](out output, columns []string, row func(T) []string, item T) error {
+	return out.write(item, columns, [][]string{row(item)})
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/escapi"
+)
+
+var organizations = &resource[escapi.Organization]{
+	scope:   scopeGlobal,
+	columns: []string{"ID", "NAME", "CREATED"},
+	row: func(o escapi.Organization) []string {
+		return []string{o.OrganizationID, o.Name, o.Created}
+	},
+	list: func(ctx context.Context, api *escapi.Client, _ location) ([]escapi.Organization, error) {
+		resp, err := api.ListOrganizations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Organizations, nil
+	},
+}
+
+var projects = &resource[escapi.Project]{
+	scope:   scopeOrganization,
+	columns: []string{"ID", "NAME", "CREATED"},
+	row: func(p escapi.Project) []string {
+		return []string{p.ProjectID, p.Name, p.Created}
+	},
+	list: func(ctx context.Context, api *escapi.Client, at location) ([]escapi.Project, error) {
+		resp, err := api.ListProjects(ctx, at.organizationID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Projects, nil
+	},
+	get: func(ctx context.Context, api *escapi.Client, at location, id string) (*escapi.Project, error) {
+		resp, err := api.GetProject(ctx, at.organizationID, id)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Project, nil
+	},
+}
+
+var networks = &resource[escapi.Network]{
+	scope:   scopeProject,
+	columns: []string{"ID", "NAME", "PROVIDER", "REGION", "CIDR BLOCK", "PUBLIC", "STATUS"},
+	row: func(n escapi.Network) []string {
+		return []string{n.NetworkID, n.Name, n.Provider, n.Region, n.CIDRBlock, fmt.Sprint(n.PublicAccess), n.Status}
+	},
+	list: func(ctx context.Context, api *escapi.Client, at location) ([]escapi.Network, error) {
+		resp, err := api.ListNetworks(ctx, at.organizationID, at.projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Networks, nil
+	},
+	get: func(ctx context.Context, api *escapi.Client, at location, id string) (*escapi.Network, error) {
+		resp, err := api.GetNetwork(ctx, at.organizationID, at.projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Network, nil
+	},
+}
+
+var peerings = &resource[escapi.Peering]{
+	scope:   scopeProject,
+	columns: []string{"ID", "NAME", "NETWORK", "PEER NETWORK", "PEER REGION", "ROUTES", "STATUS"},
+	row: func(p escapi.Peering) []string {
+		return []string{
+			p.PeeringID, p.Name, p.NetworkID, p.PeerNetworkIdentifier, p.PeerNetworkRegion,
+			strings.Join(p.Routes, ","), p.Status,
+		}
+	},
+	list: func(ctx context.Context, api *escapi.Client, at location) ([]escapi.Peering, error) {
+		resp, err := api.ListPeerings(ctx, at.organizationID, at.projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Peerings, nil
+	},
+	get: func(ctx context.Context, api *escapi.Client, at location, id string) (*escapi.Peering, error) {
+		resp, err := api.GetPeering(ctx, at.organizationID, at.projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Peering, nil
+	},
+}
+
+var clusters = &resource[escapi.ManagedCluster]{
+	scope:   scopeProject,
+	columns: []string{"ID", "NAME", "PROVIDER", "REGION", "TOPOLOGY", "INSTANCE TYPE", "VERSION", "STATUS"},
+	row: func(c escapi.ManagedCluster) []string {
+		return []string{c.ClusterID, c.Name, c.Provider, c.Region, c.Topology, c.InstanceType, c.ServerVersionTag, c.Status}
+	},
+	list: func(ctx context.Context, api *escapi.Client, at location) ([]escapi.ManagedCluster, error) {
+		resp, err := api.ListManagedClusters(ctx, at.organizationID, at.projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.ManagedClusters, nil
+	},
+	get: func(ctx context.Context, api *escapi.Client, at location, id string) (*escapi.ManagedCluster, error) {
+		resp, err := api.GetManagedCluster(ctx, at.organizationID, at.projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.ManagedCluster, nil
+	},
+}
+
+var acls = &resource[escapi.Acl]{
+	scope:   scopeProject,
+	columns: []string{"ID", "NAME", "CIDR BLOCKS", "STATUS"},
+	row: func(a escapi.Acl) []string {
+		addresses := make([]string, 0, len(a.CidrBlocks))
+		for _, cidrBlock := range a.CidrBlocks {
+			addresses = append(addresses, cidrBlock.Address)
+		}
+		return []string{a.AclID, a.Name, strings.Join(addresses, ","), a.Status}
+	},
+	list: func(ctx context.Context, api *escapi.Client, at location) ([]escapi.Acl, error) {
+		resp, err := api.ListAcls(ctx, at.organizationID, at.projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Acls, nil
+	},
+	get: func(ctx context.Context, api *escapi.Client, at location, id string) (*escapi.Acl, error) {
+		resp, err := api.GetAcl(ctx, at.organizationID, at.projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Acl, nil
+	},
+}
+
+var jobs = &resource[escapi.Job]{
+	scope:   scopeProject,
+	columns: []string{"ID", "NAME", "TYPE", "SCHEDULE", "CLUSTER", "STATUS"},
+	row: func(j escapi.Job) []string {
+		clusterID, _ := j.Data["clusterId"].(string)
+		return []string{j.Id, j.Description, j.Type, j.Schedule, clusterID, j.Status}
+	},
+	list: func(ctx context.Context, api *escapi.Client, at location) ([]escapi.Job, error) {
+		resp, err := api.ListJobs(ctx, at.organizationID, at.projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Jobs, nil
+	},
+	get: func(ctx context.Context, api *escapi.Client, at location, id string) (*escapi.Job, error) {
+		resp, err := api.GetJob(ctx, at.organizationID, at.projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Job, nil
+	},
+	create: newScheduledBackup,
+}
+
+// scheduledBackup creates a job backing up a cluster on a schedule, like the
+// provider's eventstorecloud_scheduled_backup resource
+type scheduledBackup struct {
+	description       string
+	schedule          string
+	clusterID         string
+	backupDescription string
+	maxBackupCount    int
+}
+
+func newScheduledBackup(flags *flag.FlagSet) creator {
+	b := &scheduledBackup{}
+	flags.StringVar(&b.description, "name", "", "description of the job")
+	flags.StringVar(&b.schedule, "schedule", "", "schedule of the backups, in cron syntax")
+	flags.StringVar(&b.clusterID, "cluster", "", "ID of the cluster to back up")
+	flags.StringVar(&b.backupDescription, "backup-description", "", "description of each backup")
+	flags.IntVar(&b.maxBackupCount, "max-backup-count", 0, "number of backups to keep")
+	return b
+}
+
+func (b *scheduledBackup) validate() error {
+	switch {
+	case b.description == "":
+		return usageErrorf("jobs create: -name is required")
+	case b.schedule == "":
+		return usageErrorf("jobs create: -schedule is required")
+	case b.clusterID == "":
+		return usageErrorf("jobs create: -cluster is required")
+	case b.backupDescription == "":
+		return usageErrorf("jobs create: -backup-description is required")
+	case b.maxBackupCount <= 0:
+		return usageErrorf("jobs create: -max-backup-count must be at least 1")
+	}
+	return nil
+}
+
+func (b *scheduledBackup) create(ctx context.Context, api *escapi.Client, at location) (string, error) {
+	resp, err := api.CreateJob(ctx, at.organizationID, at.projectID, &escapi.CreateJobRequest{
+		Data: map[string]interface{}{
+			"clusterId":      b.clusterID,
+			"description":    b.backupDescription,
+			"maxBackupCount": b.maxBackupCount,
+		},
+		Description: b.description,
+		Schedule:    b.schedule,
+		Type:        "ScheduledBackup",
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Id, nil
+}
+
+var integrations = &resource[escapi.Integration]{
+	scope:   scopeProject,
+	columns: []string{"ID", "NAME", "SINK", "STATUS"},
+	row: func(i escapi.Integration) []string {
+		sink, _ := i.Data["sink"].(string)
+		return []string{i.Id, i.Description, sink, string(i.Status)}
+	},
+	list: func(ctx context.Context, api *escapi.Client, at location) ([]escapi.Integration, error) {
+		resp, err := api.ListIntegrations(ctx, at.organizationID, at.projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Integrations, nil
+	},
+	get: func(ctx context.Context, api *escapi.Client, at location, id string) (*escapi.Integration, error) {
+		resp, err := api.GetIntegration(ctx, at.organizationID, at.projectID, id)
+		if err != nil {
+			return nil, err
+		}
+		return &resp.Integration, nil
+	},
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// tokenCommand inspects and manages the access token cached for the
+// configured credentials, e.g. to find out why the identity provider rejects
+// them in CI
+type tokenCommand struct{}
+
+var token = tokenCommand{}
+
+func (tokenCommand) run(ctx context.Context, s *session, name string, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("%s: an action is required, expected inspect, refresh or clear", name)
+	}
+	action := args[0]
+
+	flags := s.flagSet(name + " " + action)
+	var force bool
+	if action == "refresh" {
+		flags.BoolVar(&force, "force", false, "obtain a new token even if the cached one is valid")
+	}
+	if err := parseFlags(flags, args[1:]); err != nil {
+		return err
+	}
+
+	switch action {
+	case "inspect", "refresh", "clear":
+	default:
+		return usageErrorf("%s: unknown action %q, expected inspect, refresh or clear", name, action)
+	}
+	if flags.NArg() != 0 {
+		return usageErrorf("%s %s takes no arguments", name, action)
+	}
+	out, err := newOutput(s.output, s.stdout)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.client()
+	if err != nil {
+		return err
+	}
+	if s.config.TokenStoreType == client.TokenStoreMemory && action != "refresh" {
+		return fmt.Errorf("the %s token store keeps no tokens between runs, set ESC_TOKEN_STORE_TYPE to use another", client.TokenStoreMemory)
+	}
+
+	switch action {
+	case "refresh":
+		if err := c.TokenRefresh(ctx, force); err != nil {
+			return err
+		}
+	case "clear":
+		err := c.TokenClear()
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintln(s.stderr, "No token is cached for the configured credentials")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s.stderr, "Removed the cached token from %s\n", s.config.TokenStore)
+		return nil
+	}
+
+	info, err := c.TokenInspect(ctx)
+	if errors.Is(err, fs.ErrNotExist) {
+		return errors.New("no token is cached for the configured credentials, run `escctl token refresh` to obtain one")
+	}
+	if err != nil {
+		return err
+	}
+	return out.writeFields(info, tokenFields(info, time.Now()))
+}
+
+// tokenFields describes a token for people, with times relative to now
+func tokenFields(info *client.TokenInfo, now time.Time) [][2]string {
+	status := "valid"
+	if info.Problem != "" {
+		status = info.Problem
+	}
+	refreshToken := "none"
+	if info.HasRefreshToken {
+		refreshToken = "cached"
+	}
+
+	expiry := fmt.Sprintf("expires in %s", info.ExpiresAt.Sub(now).Round(time.Second))
+	if !now.Before(info.ExpiresAt) {
+		expiry = fmt.Sprintf("expired %s ago", now.Sub(info.ExpiresAt).Round(time.Second))
+	}
+
+	fields := [][2]string{
+		{"Key", info.Key},
+		{"Status", status},
+		{"Subject", info.Subject},
+		{"Issuer", info.Issuer},
+		{"Audience", strings.Join(info.Audience, ", ")},
+		{"Scopes", strings.Join(info.Scopes, " ")},
+		{"Issued", info.IssuedAt.Local().Format(time.RFC3339)},
+		{"Expires", fmt.Sprintf("%s (%s)", info.ExpiresAt.Local().Format(time.RFC3339), expiry)},
+		{"Client", info.ClientID},
+		{"Refresh token", refreshToken},
+	}
+
+	// Other claims, such as the organizations of the subject, are listed by
+	// name since they vary between identity providers
+	names := make([]string, 0, len(info.Claims))
+	for name := range info.Claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, [2]string{name, formatClaim(info.Claims[name])})
+	}
+
+	for i := range fields {
+		if fields[i][1] == "" {
+			fields[i][1] = "-"
+		}
+	}
+	return fields
+}
+
+func formatClaim(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
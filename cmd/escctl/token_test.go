@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestToken(t *testing.T) {
+	newTestServer(t, &clienttest.Config{
+		TokenClaims: map[string]interface{}{"https://eventstore.cloud/orgs": []string{clienttest.DefaultOrganizationID}},
+	})
+	t.Setenv("ESC_TOKEN_STORE_TYPE", client.TokenStoreFile)
+	t.Setenv("ESC_TOKEN_STORE", t.TempDir())
+
+	code, _, stderr := runCommand("token", "inspect")
+	if code != 1 || !strings.Contains(stderr, "no token is cached") {
+		t.Errorf("expected no cached token, got %d: %s", code, stderr)
+	}
+
+	code, stdout, stderr := runCommand("token", "refresh")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	for _, expected := range []string{"Status:", "valid", "user|clienttest", `https://eventstore.cloud/orgs:  ["test-organization"]`} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("expected %q in:\n%s", expected, stdout)
+		}
+	}
+
+	code, stdout, stderr = runCommand("token", "inspect", "-o", "json")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	var info client.TokenInfo
+	if err := json.Unmarshal([]byte(stdout), &info); err != nil {
+		t.Fatalf("decoding %q: %s", stdout, err)
+	}
+	if info.Subject != "user|clienttest" || info.Problem != "" || info.ExpiresAt.Before(time.Now()) {
+		t.Errorf("expected a valid token, got %+v", info)
+	}
+
+	if code, _, stderr := runCommand("token", "refresh", "-force"); code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+
+	code, _, stderr = runCommand("token", "clear")
+	if code != 0 || !strings.Contains(stderr, "Removed the cached token") {
+		t.Errorf("expected the token to be removed, got %d: %s", code, stderr)
+	}
+	code, _, stderr = runCommand("token", "clear")
+	if code != 0 || !strings.Contains(stderr, "No token is cached") {
+		t.Errorf("expected no token to be left, got %d: %s", code, stderr)
+	}
+}
+
+func TestTokenMemoryStore(t *testing.T) {
+	newTestServer(t, nil)
+
+	code, _, stderr := runCommand("token", "inspect")
+	if code != 1 || !strings.Contains(stderr, "keeps no tokens between runs") {
+		t.Errorf("expected the memory store to be rejected, got %d: %s", code, stderr)
+	}
+}
+
+func TestTokenFields(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	info := &client.TokenInfo{
+		Key:       "api.eventstore.cloud",
+		Subject:   "user|1",
+		Audience:  []string{"https://api.eventstore.cloud"},
+		IssuedAt:  now.Add(-2 * time.Hour),
+		ExpiresAt: now.Add(-time.Hour),
+		Problem:   "invalid token: exp not satisfied",
+	}
+
+	fields := map[string]string{}
+	for _, field := range tokenFields(info, now) {
+		fields[field[0]] = field[1]
+	}
+
+	if fields["Status"] != info.Problem {
+		t.Errorf("expected the problem as status, got %q", fields["Status"])
+	}
+	if !strings.HasSuffix(fields["Expires"], "(expired 1h0m0s ago)") {
+		t.Errorf("unexpected expiry %q", fields["Expires"])
+	}
+	if fields["Refresh token"] != "none" || fields["Client"] != "-" {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
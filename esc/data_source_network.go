@@ -14,6 +14,8 @@ func dataSourceNetwork() *schema.Resource {
 		Description: "Retrieves data for an existing `Network` resource",
 		ReadContext: dataSourceNetworkRead,
 		Schema: map[string]*schema.Schema{
+			"organization_id": dataSourceOrganizationIDSchema(),
+
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -54,14 +56,18 @@ func dataSourceNetworkRead(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	projectID := d.Get("project_id").(string)
 
 	resp, err := c.client.NetworkList(ctx, &client.ListNetworksRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectID,
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(resp.Networks) == 0 {
@@ -0,0 +1,52 @@
+package esc
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOrganization() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the organization the provider is configured for, whether set explicitly or discovered from the credentials",
+		ReadContext: dataSourceOrganizationRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": dataSourceOrganizationIDSchema(),
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+) diag.Diagnostics {
+	c := meta.(*providerContext)
+	orgId := c.organizationID(d)
+
+	resp, err := c.client.OrganizationList(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, org := range resp.Organizations {
+		if org.OrganizationID == orgId {
+			d.SetId(org.OrganizationID)
+			if err := d.Set("organization_id", org.OrganizationID); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set("name", org.Name); err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		}
+	}
+
+	return diag.Errorf("Organization %s is not accessible with the configured credentials", orgId)
+}
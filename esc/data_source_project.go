@@ -14,6 +14,8 @@ func dataSourceProject() *schema.Resource {
 		Description: "Retrieves data for an existing `Project` resource",
 		ReadContext: dataSourceProjectRead,
 		Schema: map[string]*schema.Schema{
+			"organization_id": dataSourceOrganizationIDSchema(),
+
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -29,15 +31,19 @@ func dataSourceProjectRead(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	resp, err := c.client.ProjectList(ctx, &client.ListProjectsRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(resp.Projects) == 0 {
-		return diag.Errorf("There are no projects in organization %s", c.organizationId)
+		return diag.Errorf("There are no projects in organization %s", c.organizationID(d))
 	}
 
 	var found []*client.Project
@@ -53,14 +59,14 @@ func dataSourceProjectRead(
 		return diag.Errorf(
 			"Project %s was not found in organization %s",
 			desiredName,
-			c.organizationId,
+			c.organizationID(d),
 		)
 	}
 	if len(found) > 1 {
 		return diag.Errorf(
 			"There are more than one project with name %s in organization %s",
 			desiredName,
-			c.organizationId,
+			c.organizationID(d),
 		)
 	}
 
@@ -0,0 +1,173 @@
+package esc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// Attributes of each resource, by the name of the API field they are sent as.
+// Fields nested in the `data` of integrations and jobs are prefixed with
+// `data.`.
+var (
+	projectAPIFields = map[string]string{
+		"name": "name",
+	}
+
+	networkAPIFields = map[string]string{
+		"description":  "name",
+		"provider":     "resource_provider",
+		"region":       "region",
+		"cidrBlock":    "cidr_block",
+		"publicAccess": "public_access",
+	}
+
+	peeringAPIFields = map[string]string{
+		"description":       "name",
+		"networkId":         "network_id",
+		"peerAccountId":     "peer_account_id",
+		"peerNetworkId":     "peer_network_id",
+		"peerNetworkRegion": "peer_network_region",
+		"routes":            "routes",
+	}
+
+	aclAPIFields = map[string]string{
+		"description": "name",
+		"cidrBlocks":  "cidr_blocks",
+	}
+
+	managedClusterAPIFields = map[string]string{
+		"description":     "name",
+		"networkId":       "network_id",
+		"topology":        "topology",
+		"instanceType":    "instance_type",
+		"targetSize":      "instance_type",
+		"diskSizeGb":      "disk_size",
+		"diskType":        "disk_type",
+		"diskIops":        "disk_iops",
+		"diskThroughput":  "disk_throughput",
+		"serverVersion":   "server_version",
+		"targetTag":       "server_version_tag",
+		"projectionLevel": "projection_level",
+		"protected":       "protected",
+		"publicAccess":    "public_access",
+		"aclId":           "acl_id",
+	}
+
+	scheduledBackupAPIFields = map[string]string{
+		"description":         "description",
+		"schedule":            "schedule",
+		"data.clusterId":      "source_cluster_id",
+		"data.description":    "backup_description",
+		"data.maxBackupCount": "max_backup_count",
+	}
+
+	integrationAPIFields = map[string]string{
+		"description": "description",
+		"data":        "data",
+	}
+
+	awsCloudWatchIntegrationAPIFields = map[string]string{
+		"description":          "description",
+		"data.accessKeyId":     "access_key_id",
+		"data.clusterIds":      "cluster_ids",
+		"data.groupName":       "group_name",
+		"data.namespace":       "namespace",
+		"data.region":          "region",
+		"data.secretAccessKey": "secret_access_key",
+	}
+)
+
+// Index of an element in a field name, e.g. `cidrBlocks[1]`
+var apiFieldIndexPattern = regexp.MustCompile(`^(.*)\[(\d+)\]$`)
+
+// apiErrorDiagnostics turns err into diagnostics. When the API rejected
+// individual fields, each gets its own diagnostic pointing at the attribute
+// it was set from, so that Terraform can highlight it.
+func apiErrorDiagnostics(err error, fields map[string]string) diag.Diagnostics {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.ProblemDetails == nil || len(apiErr.ProblemDetails.Fields) == 0 {
+		return diag.FromErr(err)
+	}
+
+	diags := diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  apiErr.Message(),
+	}}
+
+	keys := make([]string, 0, len(apiErr.ProblemDetails.Fields))
+	for key := range apiErr.ProblemDetails.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		message := apiErr.ProblemDetails.Fields[key]
+
+		attribute, path, ok := apiFieldPath(key, fields)
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Invalid value for %s", key),
+				Detail:   message,
+			})
+			continue
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value for %s", attribute),
+			Detail:        fmt.Sprintf("%s (API field %s)", message, key),
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// apiFieldPath finds the attribute the API field key was set from. Keys are
+// matched case insensitively, as the API isn't consistent about the case of
+// the first letter, and with or without a `data.` prefix. Keys pointing into
+// a list, e.g. `cidrBlocks[1].address`, resolve to the element of the list.
+func apiFieldPath(key string, fields map[string]string) (string, cty.Path, bool) {
+	segments := strings.Split(key, ".")
+	name := segments[0]
+	if strings.EqualFold(name, "data") && len(segments) > 1 {
+		name = "data." + segments[1]
+	}
+
+	index := -1
+	if match := apiFieldIndexPattern.FindStringSubmatch(name); match != nil {
+		name = match[1]
+		index, _ = strconv.Atoi(match[2])
+	}
+
+	candidates := []string{name, "data." + name}
+	if strings.HasPrefix(name, "data.") {
+		candidates = []string{name, strings.TrimPrefix(name, "data."), "data"}
+	}
+
+	for _, candidate := range candidates {
+		for field, attribute := range fields {
+			if !strings.EqualFold(field, candidate) {
+				continue
+			}
+
+			path := cty.GetAttrPath(attribute)
+			if index >= 0 {
+				path = path.IndexInt(index)
+			}
+			return attribute, path, true
+		}
+	}
+
+	return "", nil, false
+}
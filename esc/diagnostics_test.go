@@ -0,0 +1,105 @@
+package esc
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+func TestAPIFieldPath(t *testing.T) {
+	tests := []struct {
+		key       string
+		fields    map[string]string
+		attribute string
+		path      cty.Path
+	}{
+		{key: "description", fields: networkAPIFields, attribute: "name", path: cty.GetAttrPath("name")},
+		{key: "CidrBlock", fields: networkAPIFields, attribute: "cidr_block", path: cty.GetAttrPath("cidr_block")},
+		{key: "cidrBlocks[1]", fields: aclAPIFields, attribute: "cidr_blocks", path: cty.GetAttrPath("cidr_blocks").IndexInt(1)},
+		{key: "cidrBlocks[12].address", fields: aclAPIFields, attribute: "cidr_blocks", path: cty.GetAttrPath("cidr_blocks").IndexInt(12)},
+		{key: "data.clusterId", fields: scheduledBackupAPIFields, attribute: "source_cluster_id", path: cty.GetAttrPath("source_cluster_id")},
+		{key: "Data.MaxBackupCount", fields: scheduledBackupAPIFields, attribute: "max_backup_count", path: cty.GetAttrPath("max_backup_count")},
+		// The data prefix is optional in both directions
+		{key: "clusterId", fields: scheduledBackupAPIFields, attribute: "source_cluster_id", path: cty.GetAttrPath("source_cluster_id")},
+		{key: "data.description", fields: awsCloudWatchIntegrationAPIFields, attribute: "description", path: cty.GetAttrPath("description")},
+		{key: "data.clusterIds[2]", fields: awsCloudWatchIntegrationAPIFields, attribute: "cluster_ids", path: cty.GetAttrPath("cluster_ids").IndexInt(2)},
+		// Fields inside a map attribute point at the map
+		{key: "data.channelId", fields: integrationAPIFields, attribute: "data", path: cty.GetAttrPath("data")},
+		{key: "unknownField", fields: networkAPIFields},
+		{key: "data.unknownField", fields: scheduledBackupAPIFields},
+		{key: "routes[x]", fields: peeringAPIFields},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			attribute, path, ok := apiFieldPath(tt.key, tt.fields)
+			if ok != (tt.attribute != "") {
+				t.Fatalf("expected found %t, got %t (%s)", tt.attribute != "", ok, attribute)
+			}
+			if attribute != tt.attribute || !path.Equals(tt.path) {
+				t.Errorf("expected %s at %#v, got %s at %#v", tt.attribute, tt.path, attribute, path)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	t.Run("field errors", func(t *testing.T) {
+		err := &client.APIError{
+			StatusCode: http.StatusBadRequest,
+			Activity:   "creating acl",
+			ProblemDetails: &client.ProblemDetails{
+				Title:  "Bad Request",
+				Detail: "invalid request",
+				Fields: map[string]string{
+					"cidrBlocks[1].address": "must be a CIDR block",
+					"organisation":          "is unknown",
+				},
+			},
+		}
+
+		diags := apiErrorDiagnostics(err, aclAPIFields)
+
+		if len(diags) != 3 {
+			t.Fatalf("expected 3 diagnostics, got %+v", diags)
+		}
+		if diags[0].Summary != "error creating acl (status 400): Bad Request: invalid request" || diags[0].AttributePath != nil {
+			t.Errorf("expected a general diagnostic first, got %+v", diags[0])
+		}
+		if diags[1].Summary != "Invalid value for cidr_blocks" ||
+			diags[1].Detail != "must be a CIDR block (API field cidrBlocks[1].address)" ||
+			!diags[1].AttributePath.Equals(cty.GetAttrPath("cidr_blocks").IndexInt(1)) {
+			t.Errorf("expected a diagnostic for the second CIDR block, got %+v", diags[1])
+		}
+		// Unknown fields can't be pointed at, but aren't lost
+		if diags[2].Summary != "Invalid value for organisation" || diags[2].Detail != "is unknown" || diags[2].AttributePath != nil {
+			t.Errorf("expected a general diagnostic for the unknown field, got %+v", diags[2])
+		}
+	})
+
+	t.Run("no field errors", func(t *testing.T) {
+		err := &client.APIError{
+			StatusCode:     http.StatusConflict,
+			Activity:       "creating acl",
+			ProblemDetails: &client.ProblemDetails{Title: "Conflict"},
+		}
+
+		diags := apiErrorDiagnostics(err, aclAPIFields)
+
+		if len(diags) != 1 || diags[0].Summary != err.Error() {
+			t.Errorf("expected the error as a single diagnostic, got %+v", diags)
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		diags := apiErrorDiagnostics(errors.New("error sending request: connection refused"), aclAPIFields)
+
+		if len(diags) != 1 || diags[0].Summary != "error sending request: connection refused" {
+			t.Errorf("expected the error as a single diagnostic, got %+v", diags)
+		}
+	})
+}
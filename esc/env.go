@@ -0,0 +1,97 @@
+package esc
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// EnvConfig returns the client configuration given by the environment
+// variables of the provider, e.g. ESC_URL or ESC_TOKEN, so that other tools
+// authenticate the same way and share its token store. As in the provider,
+// the settings of profile, which may be nil, take precedence over their
+// environment variables, and missing settings default to the same values.
+func EnvConfig(profile *Profile) (*client.Config, error) {
+	token, err := profile.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		token = os.Getenv("ESC_TOKEN")
+	}
+	if profile == nil {
+		profile = &Profile{}
+	}
+
+	insecureSkipVerify, err := envBool("ESC_INSECURE_SKIP_VERIFY")
+	if err != nil {
+		return nil, err
+	}
+	connectTimeout, err := envSeconds("ESC_CONNECT_TIMEOUT", 30)
+	if err != nil {
+		return nil, err
+	}
+	requestTimeout, err := envSeconds("ESC_REQUEST_TIMEOUT", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.Config{
+		URL:                 firstNonEmpty(profile.URL, os.Getenv("ESC_URL"), defaultURL),
+		RefreshToken:        token,
+		TokenStore:          firstNonEmpty(os.Getenv("ESC_TOKEN_STORE"), DefaultTokenStore),
+		TokenStoreType:      firstNonEmpty(os.Getenv("ESC_TOKEN_STORE_TYPE"), client.TokenStoreFile),
+		TokenStoreKey:       os.Getenv("ESC_TOKEN_STORE_KEY"),
+		TokenStoreKeyFile:   os.Getenv("ESC_TOKEN_STORE_KEY_FILE"),
+		IdentityProviderURL: firstNonEmpty(profile.IdentityProviderURL, os.Getenv("ESC_IDENTITY_PROVIDER_URL")),
+		ClientID:            firstNonEmpty(profile.ClientID, os.Getenv("ESC_CLIENT_ID")),
+		ClientSecret:        os.Getenv("ESC_CLIENT_SECRET"),
+		OIDCToken:           os.Getenv("ESC_OIDC_TOKEN"),
+		OIDCTokenFile:       os.Getenv("ESC_OIDC_TOKEN_FILE"),
+		CAFile:              os.Getenv("ESC_CA_FILE"),
+		ClientCertFile:      os.Getenv("ESC_CLIENT_CERT"),
+		ClientKeyFile:       os.Getenv("ESC_CLIENT_KEY"),
+		ProxyURL:            os.Getenv("ESC_PROXY_URL"),
+		InsecureSkipVerify:  insecureSkipVerify,
+		ConnectTimeout:      connectTimeout,
+		RequestTimeout:      requestTimeout,
+	}, nil
+}
+
+// EnvOrganizationID returns the organization set by profile, which may be
+// nil, or failing that by ESC_ORG_ID
+func EnvOrganizationID(profile *Profile) string {
+	if profile == nil {
+		return os.Getenv("ESC_ORG_ID")
+	}
+	return firstNonEmpty(profile.OrganizationID, os.Getenv("ESC_ORG_ID"))
+}
+
+func envBool(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false, got %q", name, value)
+	}
+	return b, nil
+}
+
+func envSeconds(name string, defaultValue int) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return time.Duration(defaultValue) * time.Second, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("%s must be a number of seconds, got %q", name, value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -0,0 +1,105 @@
+package esc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+func TestEnvConfig(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("profile-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile := &Profile{
+		URL:                 "https://profile.example.com",
+		IdentityProviderURL: "https://identity.example.com",
+		ClientID:            "profile-client",
+		OrganizationID:      "profile-org",
+		TokenFile:           tokenFile,
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		config, err := EnvConfig(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.URL != defaultURL || config.TokenStore != DefaultTokenStore || config.TokenStoreType != client.TokenStoreFile {
+			t.Errorf("unexpected defaults %+v", config)
+		}
+		if config.ConnectTimeout != 30*time.Second || config.RequestTimeout != 0 {
+			t.Errorf("unexpected timeouts %s and %s", config.ConnectTimeout, config.RequestTimeout)
+		}
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		config, err := EnvConfig(profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.URL != profile.URL || config.IdentityProviderURL != profile.IdentityProviderURL || config.ClientID != profile.ClientID {
+			t.Errorf("profile settings were not used: %+v", config)
+		}
+		if config.RefreshToken != "profile-token" {
+			t.Errorf("expected the profile token, got %q", config.RefreshToken)
+		}
+		if organizationID := EnvOrganizationID(profile); organizationID != "profile-org" {
+			t.Errorf("expected the profile organization, got %q", organizationID)
+		}
+	})
+
+	t.Run("profile overrides environment", func(t *testing.T) {
+		t.Setenv("ESC_URL", "https://env.example.com")
+		t.Setenv("ESC_TOKEN", "env-token")
+		t.Setenv("ESC_ORG_ID", "env-org")
+		t.Setenv("ESC_INSECURE_SKIP_VERIFY", "true")
+		t.Setenv("ESC_REQUEST_TIMEOUT", "90")
+
+		config, err := EnvConfig(profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.URL != profile.URL || config.RefreshToken != "profile-token" {
+			t.Errorf("profile settings were not used: %+v", config)
+		}
+		if !config.InsecureSkipVerify || config.RequestTimeout != 90*time.Second {
+			t.Errorf("unexpected TLS verification or timeout: %+v", config)
+		}
+		if organizationID := EnvOrganizationID(profile); organizationID != "profile-org" {
+			t.Errorf("expected the profile organization, got %q", organizationID)
+		}
+	})
+
+	t.Run("environment fills in the profile", func(t *testing.T) {
+		t.Setenv("ESC_URL", "https://env.example.com")
+		t.Setenv("ESC_TOKEN", "env-token")
+		t.Setenv("ESC_ORG_ID", "env-org")
+
+		config, err := EnvConfig(&Profile{ClientID: "profile-client"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if config.URL != "https://env.example.com" || config.RefreshToken != "env-token" || config.ClientID != "profile-client" {
+			t.Errorf("environment settings were not used: %+v", config)
+		}
+		if organizationID := EnvOrganizationID(&Profile{}); organizationID != "env-org" {
+			t.Errorf("expected the environment organization, got %q", organizationID)
+		}
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		t.Setenv("ESC_CONNECT_TIMEOUT", "1m")
+
+		if _, err := EnvConfig(nil); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
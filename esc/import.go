@@ -8,30 +8,38 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// Parse an import id using {project_id}:{id} structure
-func parseImportID(importId string) ([]string, error) {
+// Parse an import id using {project_id}:{id} structure, optionally prefixed
+// with the organization as {organization_id}:{project_id}:{id}. The
+// organization is empty when not given.
+func parseImportID(importId string) (string, []string, error) {
 	result := strings.Split(importId, ":")
 
-	if len(result) != 2 {
-		return nil, fmt.Errorf(
-			"Failed to parse import id. Please use the format `{project_id}:{resource_id}`",
-		)
+	switch len(result) {
+	case 2:
+		return "", result, nil
+	case 3:
+		return result[0], result[1:], nil
 	}
 
-	return result, nil
+	return "", nil, fmt.Errorf(
+		"Failed to parse import id. Please use the format `{project_id}:{resource_id}` or `{organization_id}:{project_id}:{resource_id}`",
+	)
 }
 
-// Help to set a proper project_id and resource id for import
+// Help to set a proper organization_id, project_id and resource id for import
 func resourceImport(
 	ctx context.Context,
 	d *schema.ResourceData,
 	m interface{},
 ) ([]*schema.ResourceData, error) {
-	idSlice, err := parseImportID(d.Id())
+	orgId, idSlice, err := parseImportID(d.Id())
 	if err != nil {
 		return nil, err
 	}
 
+	if err := setImportedOrganizationID(d, orgId); err != nil {
+		return nil, err
+	}
 	if err := d.Set("project_id", idSlice[0]); err != nil {
 		return nil, err
 	}
@@ -39,3 +47,34 @@ func resourceImport(
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// Projects are imported with {project_id} or {organization_id}:{project_id}
+func resourceProjectImport(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) ([]*schema.ResourceData, error) {
+	idSlice := strings.Split(d.Id(), ":")
+
+	switch len(idSlice) {
+	case 1:
+	case 2:
+		if err := setImportedOrganizationID(d, idSlice[0]); err != nil {
+			return nil, err
+		}
+		d.SetId(idSlice[1])
+	default:
+		return nil, fmt.Errorf(
+			"Failed to parse import id. Please use the format `{project_id}` or `{organization_id}:{project_id}`",
+		)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setImportedOrganizationID(d *schema.ResourceData, orgId string) error {
+	if orgId == "" {
+		return nil
+	}
+	return d.Set("organization_id", orgId)
+}
@@ -0,0 +1,55 @@
+package esc
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// discoverOrganization returns the only organization accessible with the
+// configured credentials, for when `organization_id` isn't set.
+func discoverOrganization(ctx context.Context, c *client.Client) (string, error) {
+	org, err := c.OrganizationDiscover(ctx, "organization_id")
+	if err != nil {
+		return "", err
+	}
+
+	tflog.Info(ctx, "Using the only organization accessible", map[string]interface{}{
+		"organization_id":   org.OrganizationID,
+		"organization_name": org.Name,
+	})
+	return org.OrganizationID, nil
+}
+
+// Resources may belong to another organization than the provider's, so that
+// several organizations can be managed from the same configuration.
+func organizationIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "ID of the organization the resource belongs to. Defaults to the provider's organization",
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Type:        schema.TypeString,
+	}
+}
+
+func dataSourceOrganizationIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "ID of the organization to look in. Defaults to the provider's organization",
+		Optional:    true,
+		Computed:    true,
+		Type:        schema.TypeString,
+	}
+}
+
+// organizationID returns the organization set on the resource, which is kept
+// in state once known, or else the provider's organization.
+func (c *providerContext) organizationID(d *schema.ResourceData) string {
+	if orgId, ok := d.GetOk("organization_id"); ok {
+		return orgId.(string)
+	}
+	return c.organizationId
+}
@@ -0,0 +1,114 @@
+package esc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultConfigFile holds named profiles, and may be moved with
+// ESC_CONFIG_FILE
+var DefaultConfigFile = filepath.Join(os.Getenv("HOME"), ".esc", "config")
+
+// Profile used when none is selected, if the config file defines it
+const defaultProfileName = "default"
+
+// Profile holds the settings for one Event Store Cloud environment. The
+// refresh token is never stored in the file itself, only a reference to it.
+type Profile struct {
+	URL                 string `toml:"url"`
+	IdentityProviderURL string `toml:"identity_provider_url"`
+	ClientID            string `toml:"client_id"`
+	OrganizationID      string `toml:"organization_id"`
+	// Name of an environment variable holding the refresh token
+	TokenEnv string `toml:"token_env"`
+	// Path to a file holding the refresh token, which may start with ~/
+	TokenFile string `toml:"token_file"`
+}
+
+type configFile struct {
+	Profiles map[string]*Profile `toml:"profiles"`
+}
+
+func configFilePath() string {
+	if path := os.Getenv("ESC_CONFIG_FILE"); path != "" {
+		return path
+	}
+	return DefaultConfigFile
+}
+
+// LoadProfile reads the named profile from the config file. When name is
+// empty, the `default` profile is returned if there is one, and nil
+// otherwise.
+func LoadProfile(name string) (*Profile, error) {
+	path := configFilePath()
+
+	config := configFile{}
+	if _, err := toml.DecodeFile(path, &config); err != nil {
+		if errors.Is(err, fs.ErrNotExist) && name == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading config file %q: %w", path, err)
+	}
+
+	if name == "" {
+		return config.Profiles[defaultProfileName], nil
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(config.Profiles))
+		for n := range config.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("profile %q is not defined in %q, available profiles: %s", name, path, strings.Join(names, ", "))
+	}
+
+	return profile, nil
+}
+
+// Token resolves the refresh token referenced by the profile
+func (p *Profile) Token() (string, error) {
+	if p == nil {
+		return "", nil
+	}
+
+	switch {
+	case p.TokenEnv != "" && p.TokenFile != "":
+		return "", errors.New("a profile may only set one of token_env and token_file")
+	case p.TokenEnv != "":
+		return os.Getenv(p.TokenEnv), nil
+	case p.TokenFile != "":
+		path := p.TokenFile
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			path = filepath.Join(os.Getenv("HOME"), rest)
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading token file %q: %w", path, err)
+		}
+		return strings.TrimSpace(string(contents)), nil
+	}
+
+	return "", nil
+}
+
+// firstNonEmpty implements the precedence used when resolving settings:
+// provider attributes, then the profile, then environment variables, then
+// built-in defaults.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,284 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// writeConfigFile writes the profiles config file and points
+// ESC_CONFIG_FILE at it
+func writeConfigFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ESC_CONFIG_FILE", path)
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("ESC_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+
+		profile, err := LoadProfile("")
+		if err != nil || profile != nil {
+			t.Errorf("expected no profile without a config file, got %+v (%v)", profile, err)
+		}
+		if _, err := LoadProfile("staging"); err == nil || !strings.Contains(err.Error(), "error reading config file") {
+			t.Errorf("expected a named profile to require the config file, got %v", err)
+		}
+	})
+
+	writeConfigFile(t, `
+[profiles.default]
+url = "https://default.example.com"
+
+[profiles.staging]
+url = "https://staging.example.com"
+organization_id = "staging-org"
+token_env = "STAGING_TOKEN"
+`)
+
+	t.Run("default profile", func(t *testing.T) {
+		profile, err := LoadProfile("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if profile == nil || profile.URL != "https://default.example.com" {
+			t.Errorf("expected the default profile, got %+v", profile)
+		}
+	})
+
+	t.Run("named profile", func(t *testing.T) {
+		profile, err := LoadProfile("staging")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if profile == nil || profile.OrganizationID != "staging-org" || profile.TokenEnv != "STAGING_TOKEN" {
+			t.Errorf("expected the staging profile, got %+v", profile)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := LoadProfile("production")
+		if err == nil || !strings.Contains(err.Error(), `profile "production" is not defined`) {
+			t.Fatalf("expected an unknown profile error, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "available profiles: default, staging") {
+			t.Errorf("expected the available profiles to be listed, got %v", err)
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		writeConfigFile(t, "[profiles.default\n")
+
+		if _, err := LoadProfile(""); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestProfileToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, "token"), []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("STAGING_TOKEN", "env-token")
+
+	tests := []struct {
+		name    string
+		profile *Profile
+		token   string
+		err     string
+	}{
+		{
+			name: "no profile",
+		},
+		{
+			name:    "no token",
+			profile: &Profile{},
+		},
+		{
+			name:    "environment variable",
+			profile: &Profile{TokenEnv: "STAGING_TOKEN"},
+			token:   "env-token",
+		},
+		{
+			name:    "file in the home directory",
+			profile: &Profile{TokenFile: "~/token"},
+			token:   "file-token",
+		},
+		{
+			name:    "missing file",
+			profile: &Profile{TokenFile: filepath.Join(home, "missing")},
+			err:     "error reading token file",
+		},
+		{
+			name:    "both environment variable and file",
+			profile: &Profile{TokenEnv: "STAGING_TOKEN", TokenFile: "~/token"},
+			err:     "only set one of token_env and token_file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.profile.Token()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token != tt.token {
+				t.Errorf("expected token %q, got %q", tt.token, token)
+			}
+		})
+	}
+}
+
+// Settings are taken from the provider block, then the profile, then the
+// environment. Each case only gives the right URL and organization at the
+// level expected to win, so the client only works when precedence is
+// respected.
+func TestProviderProfilePrecedence(t *testing.T) {
+	server := clienttest.NewServer(nil)
+	t.Cleanup(server.Close)
+	const wrongURL = "http://wrong.invalid"
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(clienttest.RefreshToken), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		profile      Profile
+		env          map[string]string
+		block        map[string]interface{}
+		organization string
+	}{
+		{
+			name: "profile",
+			profile: Profile{
+				URL:            server.URL,
+				OrganizationID: "profile-org",
+			},
+			organization: "profile-org",
+		},
+		{
+			name: "profile overrides environment",
+			profile: Profile{
+				URL:            server.URL,
+				OrganizationID: "profile-org",
+			},
+			env:          map[string]string{"ESC_URL": wrongURL, "ESC_ORG_ID": "env-org"},
+			organization: "profile-org",
+		},
+		{
+			name:         "environment fills in the profile",
+			env:          map[string]string{"ESC_URL": server.URL, "ESC_ORG_ID": "env-org"},
+			organization: "env-org",
+		},
+		{
+			name: "provider block overrides profile and environment",
+			profile: Profile{
+				URL:            wrongURL,
+				OrganizationID: "profile-org",
+			},
+			env:          map[string]string{"ESC_URL": wrongURL, "ESC_ORG_ID": "env-org"},
+			block:        map[string]interface{}{"url": server.URL, "organization_id": "block-org"},
+			organization: "block-org",
+		},
+		{
+			name: "profile token overrides environment token",
+			profile: Profile{
+				URL:            server.URL,
+				OrganizationID: "profile-org",
+				TokenEnv:       "PROFILE_TOKEN",
+			},
+			env:          map[string]string{"PROFILE_TOKEN": clienttest.RefreshToken, "ESC_TOKEN": "wrong-token"},
+			organization: "profile-org",
+		},
+		{
+			name: "environment token fills in the profile",
+			profile: Profile{
+				URL:            server.URL,
+				OrganizationID: "profile-org",
+				TokenEnv:       "PROFILE_TOKEN",
+			},
+			env:          map[string]string{"ESC_TOKEN": clienttest.RefreshToken},
+			organization: "profile-org",
+		},
+		{
+			name: "provider token overrides profile token",
+			profile: Profile{
+				URL:            server.URL,
+				OrganizationID: "profile-org",
+				TokenEnv:       "PROFILE_TOKEN",
+			},
+			env:          map[string]string{"PROFILE_TOKEN": "wrong-token", "ESC_TOKEN": "wrong-token"},
+			block:        map[string]interface{}{"token": clienttest.RefreshToken},
+			organization: "profile-org",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ESC_URL", "")
+			t.Setenv("ESC_ORG_ID", "")
+			t.Setenv("ESC_TOKEN", "")
+			t.Setenv("ESC_PROFILE", "")
+			t.Setenv("ESC_TOKEN_STORE_TYPE", client.TokenStoreMemory)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			profile := tt.profile
+			profile.IdentityProviderURL = server.URL
+			if profile.TokenEnv == "" {
+				profile.TokenFile = tokenFile
+			}
+			writeConfigFile(t, fmt.Sprintf(`
+[profiles.production]
+url = %q
+identity_provider_url = %q
+organization_id = %q
+token_env = %q
+token_file = %q
+`, profile.URL, profile.IdentityProviderURL, profile.OrganizationID, profile.TokenEnv, profile.TokenFile))
+
+			block := map[string]interface{}{"profile": "production"}
+			for name, value := range tt.block {
+				block[name] = value
+			}
+
+			p := New("test")()
+			if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(block)); diags.HasError() {
+				t.Fatalf("configuring provider: %+v", diags)
+			}
+			meta := p.Meta().(*providerContext)
+
+			if meta.organizationId != tt.organization {
+				t.Errorf("expected organization %q, got %q", tt.organization, meta.organizationId)
+			}
+			if _, err := meta.client.OrganizationList(context.Background()); err != nil {
+				t.Errorf("expected the client to reach the API: %s", err)
+			}
+		})
+	}
+}
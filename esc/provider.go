@@ -6,15 +6,22 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/EventStore/terraform-provider-eventstorecloud/client"
 )
 
-var defaultTokenStore = filepath.Join(os.Getenv("HOME"), ".esctf", "tokens")
+const defaultURL = "https://api.eventstore.cloud"
+
+// DefaultTokenStore is where access tokens are cached unless configured
+// otherwise
+var DefaultTokenStore = filepath.Join(os.Getenv("HOME"), ".esctf", "tokens")
 
 func init() {
 	schema.DescriptionKind = schema.StringMarkdown
@@ -35,47 +42,143 @@ func New(version string) func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			Schema: map[string]*schema.Schema{
+				// Settings which profiles hold read their environment
+				// variable in configure, where the selected profile takes
+				// precedence over it
 				"url": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"profile": {
 					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("ESC_URL", "https://api.eventstore.cloud"),
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_PROFILE", ""),
 				},
 
 				"token": {
-					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("ESC_TOKEN", ""),
-					Sensitive:   true,
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
 				},
 
 				"organization_id": {
-					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("ESC_ORG_ID", ""),
+					Type:     schema.TypeString,
+					Optional: true,
 				},
 
 				"token_store": {
 					Type:        schema.TypeString,
 					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("ESC_TOKEN_STORE", defaultTokenStore),
+					DefaultFunc: schema.EnvDefaultFunc("ESC_TOKEN_STORE", DefaultTokenStore),
 				},
 
-				"identity_provider_url": {
+				"token_store_type": {
 					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("ESC_IDENTITY_PROVIDER_URL", ""),
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_TOKEN_STORE_TYPE", client.TokenStoreFile),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+						client.TokenStoreFile,
+						client.TokenStoreEncrypted,
+						client.TokenStoreMemory,
+					}, false)),
+				},
+
+				"token_store_key": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_TOKEN_STORE_KEY", ""),
+					Sensitive:   true,
+				},
+
+				"token_store_key_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_TOKEN_STORE_KEY_FILE", ""),
+				},
+
+				"identity_provider_url": {
+					Type:     schema.TypeString,
+					Optional: true,
 				},
 
 				"client_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+
+				"client_secret": {
 					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("ESC_CLIENT_ID", ""),
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_CLIENT_SECRET", ""),
+					Sensitive:   true,
+				},
+
+				"oidc_token": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_OIDC_TOKEN", ""),
+					Sensitive:   true,
+				},
+
+				"oidc_token_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_OIDC_TOKEN_FILE", ""),
+				},
+
+				"ca_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_CA_FILE", ""),
+				},
+
+				"client_cert": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("ESC_CLIENT_CERT", ""),
+					RequiredWith: []string{"client_key"},
+				},
+
+				"client_key": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("ESC_CLIENT_KEY", ""),
+					RequiredWith: []string{"client_cert"},
+				},
+
+				"proxy_url": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("ESC_PROXY_URL", ""),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithScheme([]string{"http", "https", "socks5"})),
+				},
+
+				"insecure_skip_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("ESC_INSECURE_SKIP_VERIFY", false),
+				},
+
+				"connect_timeout": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("ESC_CONNECT_TIMEOUT", 30),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				},
+
+				"request_timeout": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("ESC_REQUEST_TIMEOUT", 0),
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 				},
 			},
 
 			DataSourcesMap: map[string]*schema.Resource{
-				"eventstorecloud_project": dataSourceProject(),
-				"eventstorecloud_network": dataSourceNetwork(),
+				"eventstorecloud_organization": dataSourceOrganization(),
+				"eventstorecloud_project":      dataSourceProject(),
+				"eventstorecloud_network":      dataSourceNetwork(),
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -91,6 +194,9 @@ func New(version string) func() *schema.Provider {
 			},
 		}
 
+		withRequestIDs(p.ResourcesMap)
+		withRequestIDs(p.DataSourcesMap)
+
 		p.ConfigureContextFunc = configure(version, p)
 
 		return p
@@ -115,25 +221,97 @@ func configure(
 	version string,
 	p *schema.Provider,
 ) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	return func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		profileName := d.Get("profile").(string)
+		profile, err := LoadProfile(profileName)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if profile == nil {
+			profile = &Profile{}
+		} else {
+			tflog.Debug(ctx, "Using profile", map[string]interface{}{"profile": firstNonEmpty(profileName, defaultProfileName)})
+		}
+
+		token := d.Get("token").(string)
+		if token == "" {
+			if token, err = profile.Token(); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+		if token == "" {
+			token = os.Getenv("ESC_TOKEN")
+		}
+
 		config := &client.Config{
-			URL:                 d.Get("url").(string),
-			RefreshToken:        d.Get("token").(string),
+			URL:                 firstNonEmpty(profileSetting(ctx, d.Get("url").(string), profile.URL, "ESC_URL"), defaultURL),
+			RefreshToken:        token,
 			TokenStore:          d.Get("token_store").(string),
-			IdentityProviderURL: d.Get("identity_provider_url").(string),
-			ClientID:            d.Get("client_id").(string),
+			TokenStoreType:      d.Get("token_store_type").(string),
+			TokenStoreKey:       d.Get("token_store_key").(string),
+			TokenStoreKeyFile:   d.Get("token_store_key_file").(string),
+			IdentityProviderURL: profileSetting(ctx, d.Get("identity_provider_url").(string), profile.IdentityProviderURL, "ESC_IDENTITY_PROVIDER_URL"),
+			ClientID:            profileSetting(ctx, d.Get("client_id").(string), profile.ClientID, "ESC_CLIENT_ID"),
+			ClientSecret:        d.Get("client_secret").(string),
+			OIDCToken:           d.Get("oidc_token").(string),
+			OIDCTokenFile:       d.Get("oidc_token_file").(string),
+			CAFile:              d.Get("ca_file").(string),
+			ClientCertFile:      d.Get("client_cert").(string),
+			ClientKeyFile:       d.Get("client_key").(string),
+			ProxyURL:            d.Get("proxy_url").(string),
+			InsecureSkipVerify:  d.Get("insecure_skip_verify").(bool),
+			ConnectTimeout:      time.Duration(d.Get("connect_timeout").(int)) * time.Second,
+			RequestTimeout:      time.Duration(d.Get("request_timeout").(int)) * time.Second,
+			UserAgent:           fmt.Sprintf("terraform-provider-eventstorecloud/%s terraform/%s", version, p.TerraformVersion),
+		}
+
+		var diags diag.Diagnostics
+		if config.InsecureSkipVerify {
+			tflog.Warn(ctx, "TLS certificate verification is disabled")
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "TLS certificate verification is disabled",
+				Detail: "insecure_skip_verify is set, so the certificates of Event Store Cloud and its identity provider " +
+					"are not verified and anyone on the network path can intercept your tokens. Use ca_file to trust " +
+					"the CA of an intercepting proxy instead.",
+				AttributePath: cty.GetAttrPath("insecure_skip_verify"),
+			})
 		}
 
 		c, err := client.New(config)
 		if err != nil {
-			return nil, diag.FromErr(err)
+			return nil, append(diags, diag.FromErr(err)...)
+		}
+
+		organizationId := profileSetting(ctx, d.Get("organization_id").(string), profile.OrganizationID, "ESC_ORG_ID")
+		if organizationId == "" {
+			if organizationId, err = discoverOrganization(ctx, c); err != nil {
+				return nil, append(diags, diag.FromErr(err)...)
+			}
 		}
 
 		return &providerContext{
-			organizationId: d.Get("organization_id").(string),
+			organizationId: organizationId,
 			client:         c,
-		}, nil
+		}, diags
+	}
+}
+
+// profileSetting resolves a setting which profiles hold: the provider
+// attribute, then the profile, then the environment variable. Since the
+// profile was selected on purpose, it hides the variable, which is logged.
+func profileSetting(ctx context.Context, attribute string, profileValue string, envName string) string {
+	env := os.Getenv(envName)
+	switch {
+	case attribute != "":
+		return attribute
+	case profileValue != "":
+		if env != "" && env != profileValue {
+			tflog.Warn(ctx, "Ignoring environment variable set by the profile as well", map[string]interface{}{"variable": envName})
+		}
+		return profileValue
 	}
+	return env
 }
 
 type providerContext struct {
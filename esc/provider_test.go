@@ -0,0 +1,288 @@
+package esc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// The acceptance tests in this package run Terraform against the fake API of
+// the clienttest package rather than Event Store Cloud, so they need nothing
+// but a terraform binary and TF_ACC to be set. Behavior that doesn't need
+// Terraform itself is tested by planning and applying resources directly.
+
+func TestProvider(t *testing.T) {
+	if err := New("test")().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestServer starts a fake Event Store Cloud API for the duration of the
+// test, and keeps the provider from picking up the profiles of whoever runs
+// the tests
+func newTestServer(t *testing.T) *clienttest.Server {
+	t.Helper()
+
+	t.Setenv("ESC_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+
+	server := clienttest.NewServer(nil)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testAccProviderFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"eventstorecloud": func() (*schema.Provider, error) {
+			return New("test")(), nil
+		},
+	}
+}
+
+// testAccProviderConfig configures the provider to use the fake server
+func testAccProviderConfig(server *clienttest.Server) string {
+	return fmt.Sprintf(`
+provider "eventstorecloud" {
+  url                   = %q
+  identity_provider_url = %q
+  token                 = %q
+  token_store_type      = "memory"
+  organization_id       = %q
+}
+`, server.URL, server.URL, clienttest.RefreshToken, clienttest.DefaultOrganizationID)
+}
+
+// testAccImportStateID returns the {project_id}:{id} import ID of a resource,
+// prefixed with its organization when withOrganization is set
+func testAccImportStateID(resourceName string, withOrganization bool) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		id := rs.Primary.Attributes["project_id"] + ":" + rs.Primary.ID
+		if withOrganization {
+			id = rs.Primary.Attributes["organization_id"] + ":" + id
+		}
+		return id, nil
+	}
+}
+
+// testAccCheckDestroy checks that every resource of the given type left in
+// the state is gone from the API, either because it can no longer be found
+// or because getStatus reports it deleted
+func testAccCheckDestroy(
+	server *clienttest.Server,
+	resourceType string,
+	getStatus func(context.Context, *client.Client, *terraform.ResourceState) (string, error),
+) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c, err := server.NewClient()
+		if err != nil {
+			return err
+		}
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			status, err := getStatus(context.Background(), c, rs)
+			if errors.Is(err, client.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if status != client.StateDeleted {
+				return fmt.Errorf("%s %s still exists with status %q", resourceType, rs.Primary.ID, status)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testMeta returns the provider context Terraform would configure for the
+// fake server, for calling resources directly
+func testMeta(t *testing.T, server *clienttest.Server) *providerContext {
+	t.Helper()
+
+	c, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &providerContext{
+		organizationId: clienttest.DefaultOrganizationID,
+		client:         c,
+	}
+}
+
+// testPlan diffs the configuration of a resource against its state, as
+// Terraform does when planning. The diff is nil when nothing changes.
+func testPlan(
+	meta *providerContext,
+	resourceType string,
+	state *terraform.InstanceState,
+	config map[string]interface{},
+) (*terraform.InstanceDiff, error) {
+	r := New("test")().ResourcesMap[resourceType]
+	return r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+}
+
+// testApply plans and applies the configuration of a resource, returning the
+// new state
+func testApply(
+	meta *providerContext,
+	resourceType string,
+	state *terraform.InstanceState,
+	config map[string]interface{},
+) (*terraform.InstanceState, diag.Diagnostics) {
+	diff, err := testPlan(meta, resourceType, state, config)
+	if err != nil {
+		return state, diag.FromErr(err)
+	}
+	if diff == nil {
+		return state, nil
+	}
+
+	r := New("test")().ResourcesMap[resourceType]
+	return r.Apply(context.Background(), state, diff, meta)
+}
+
+// mustApply is testApply for steps expected to succeed
+func mustApply(
+	t *testing.T,
+	meta *providerContext,
+	resourceType string,
+	state *terraform.InstanceState,
+	config map[string]interface{},
+) *terraform.InstanceState {
+	t.Helper()
+
+	state, diags := testApply(meta, resourceType, state, config)
+	if diags.HasError() {
+		t.Fatalf("applying %s: %+v", resourceType, diags)
+	}
+	if state == nil || state.ID == "" {
+		t.Fatalf("applying %s left no resource in state", resourceType)
+	}
+	return state
+}
+
+// testConfigure configures the provider as Terraform would with the given
+// provider block, for the fake server
+func testConfigure(t *testing.T, server *clienttest.Server, block map[string]interface{}) (*providerContext, diag.Diagnostics) {
+	t.Helper()
+
+	config := map[string]interface{}{
+		"url":                   server.URL,
+		"identity_provider_url": server.URL,
+		"token_store_type":      client.TokenStoreMemory,
+		"organization_id":       clienttest.DefaultOrganizationID,
+	}
+	for name, value := range block {
+		config[name] = value
+	}
+
+	p := New("test")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	meta, _ := p.Meta().(*providerContext)
+	return meta, diags
+}
+
+func TestProviderTokenExchange(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("ESC_TOKEN", "")
+	t.Setenv("ESC_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	server := clienttest.NewServer(&clienttest.Config{SubjectTokens: []string{"workload-oidc-token"}})
+	t.Cleanup(server.Close)
+
+	t.Run("accepted", func(t *testing.T) {
+		meta, diags := testConfigure(t, server, map[string]interface{}{"oidc_token": "workload-oidc-token"})
+		if diags.HasError() {
+			t.Fatalf("configuring provider: %+v", diags)
+		}
+
+		project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+			"name": "Test Project",
+		})
+
+		for _, request := range server.Requests() {
+			if strings.Contains(request.Path, project.ID) && request.Subject != "workload|clienttest" {
+				t.Errorf("expected %s %s to use the exchanged token, got subject %q", request.Method, request.Path, request.Subject)
+			}
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		meta, diags := testConfigure(t, server, map[string]interface{}{"oidc_token": "expired-oidc-token"})
+		if diags.HasError() {
+			t.Fatalf("configuring provider: %+v", diags)
+		}
+
+		r := New("test")().ResourcesMap["eventstorecloud_project"]
+		d := r.Data(&terraform.InstanceState{ID: "project"})
+		diags = r.ReadContext(ctx, d, meta)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "invalid_grant: unknown or invalid subject token") {
+			t.Errorf("expected the identity provider's error as a diagnostic, got %+v", diags)
+		}
+	})
+}
+
+// countPolls returns the number of reads of the resource with the given ID
+// since the first skipped requests
+func countPolls(server *clienttest.Server, skip int, id string) int {
+	polls := 0
+	for _, request := range server.Requests()[skip:] {
+		if request.Method == http.MethodGet && strings.HasSuffix(request.Path, "/"+id) {
+			polls++
+		}
+	}
+	return polls
+}
+
+// testCreateTimeout creates a resource which stays provisioning with a create
+// timeout of a second in its timeouts block, checking that the wait for it
+// gives up in time and reports the state it was last seen in
+func testCreateTimeout(
+	t *testing.T,
+	server *clienttest.Server,
+	meta *providerContext,
+	resourceType string,
+	config map[string]interface{},
+) {
+	t.Helper()
+
+	config["timeouts"] = []interface{}{
+		map[string]interface{}{"create": "1s"},
+	}
+	// Far more reads than the wait can make within its timeout
+	server.SetPendingReads(1000)
+
+	start := time.Now()
+	_, diags := testApply(meta, resourceType, nil, config)
+	if !diags.HasError() {
+		t.Fatalf("expected creating %s to time out", resourceType)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the create timeout to apply, waited %s", elapsed)
+	}
+	if summary := diags[0].Summary; !strings.Contains(summary, "timed out waiting for") || !strings.Contains(summary, `last seen in state "provisioning"`) {
+		t.Errorf("expected a timeout reporting the last state, got %q", summary)
+	}
+}
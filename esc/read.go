@@ -0,0 +1,28 @@
+package esc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+// When a resource has been deleted outside of Terraform (for example in the
+// console) the API answers with a 404. Dropping the resource from state lets
+// Terraform plan to create it again instead of failing the refresh.
+func handleReadError(ctx context.Context, d *schema.ResourceData, kind string, err error) diag.Diagnostics {
+	if errors.Is(err, client.ErrNotFound) {
+		tflog.Warn(ctx, "Resource not found, removing from state", map[string]interface{}{
+			"resource_kind": kind,
+			"id":            d.Id(),
+		})
+		d.SetId("")
+		return nil
+	}
+
+	return diag.FromErr(err)
+}
@@ -0,0 +1,73 @@
+package esc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestReadRemovesDeletedResources(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+	r := New("test")().ResourcesMap["eventstorecloud_project"]
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+
+	// Deleted outside of Terraform, e.g. in the console
+	err := meta.client.ProjectDelete(ctx, &client.DeleteProjectRequest{
+		OrganizationID: clienttest.DefaultOrganizationID,
+		ProjectID:      project.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := r.Data(project)
+	if diags := r.ReadContext(ctx, d, meta); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the project to be removed from the state, got ID %q", d.Id())
+	}
+}
+
+func TestReadReturnsOtherErrors(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+	r := New("test")().ResourcesMap["eventstorecloud_project"]
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "server error", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.InjectFault(clienttest.Fault{Method: http.MethodGet, PathPrefix: "/resources/v1/organizations/", StatusCode: tt.status})
+			t.Cleanup(server.ClearFaults)
+
+			d := r.Data(project)
+			diags := r.ReadContext(ctx, d, meta)
+			if !diags.HasError() {
+				t.Fatal("expected the error to be reported")
+			}
+			if d.Id() != project.ID {
+				t.Errorf("expected the project to be kept in the state, got ID %q", d.Id())
+			}
+		})
+	}
+}
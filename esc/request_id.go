@@ -0,0 +1,52 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+)
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// withRequestIDs gives every operation on the resources and data sources of
+// the provider its own request ID, sent with each API call it makes and
+// reported in its error diagnostics, so that a failed apply can be matched
+// with the API's own logs.
+func withRequestIDs(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		r.CreateContext = withRequestID(r.CreateContext)
+		r.ReadContext = withRequestID(r.ReadContext)
+		r.UpdateContext = withRequestID(r.UpdateContext)
+		r.DeleteContext = withRequestID(r.DeleteContext)
+	}
+}
+
+func withRequestID(f crudFunc) crudFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		requestID := client.NewRequestID()
+		ctx = client.WithRequestID(ctx, requestID)
+		ctx = tflog.SetField(ctx, "request_id", requestID)
+
+		diags := f(ctx, d, meta)
+		for i := range diags {
+			if diags[i].Severity != diag.Error {
+				continue
+			}
+			if diags[i].Detail == "" {
+				diags[i].Detail = fmt.Sprintf("Request ID: %s", requestID)
+			} else {
+				diags[i].Detail = fmt.Sprintf("%s\n\nRequest ID: %s", diags[i].Detail, requestID)
+			}
+		}
+		return diags
+	}
+}
@@ -0,0 +1,92 @@
+package esc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+// apiRequests returns the API calls made since the first skipped requests,
+// leaving out those to the identity provider
+func apiRequests(server *clienttest.Server, skip int) []clienttest.Request {
+	var requests []clienttest.Request
+	for _, request := range server.Requests()[skip:] {
+		if !strings.HasPrefix(request.Path, "/oauth/") && !strings.HasPrefix(request.Path, "/.well-known/") {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func TestOperationsShareRequestID(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+
+	// The network is read once while provisioning, so that creating it polls
+	server.SetPendingReads(1)
+	before := len(server.Requests())
+	mustApply(t, meta, "eventstorecloud_network", nil, map[string]interface{}{
+		"project_id":        project.ID,
+		"resource_provider": "aws",
+		"region":            "us-west-2",
+		"cidr_block":        "172.21.0.0/16",
+		"name":              "Test Network",
+	})
+
+	requests := apiRequests(server, before)
+	if len(requests) < 3 {
+		t.Fatalf("expected the network to be created, polled and read, got %+v", requests)
+	}
+	requestID := requests[0].RequestID
+	for _, request := range requests {
+		if request.RequestID != requestID {
+			t.Errorf("expected every call of the create to send request ID %q, %s %s sent %q", requestID, request.Method, request.Path, request.RequestID)
+		}
+	}
+
+	r := New("test")().ResourcesMap["eventstorecloud_project"]
+	before = len(server.Requests())
+	if diags := r.ReadContext(context.Background(), r.Data(project), meta); diags.HasError() {
+		t.Fatalf("reading project: %+v", diags)
+	}
+	for _, request := range apiRequests(server, before) {
+		if request.RequestID == requestID {
+			t.Errorf("expected another operation to send another request ID, %s %s sent %q", request.Method, request.Path, request.RequestID)
+		}
+	}
+}
+
+func TestErrorDiagnosticsIncludeRequestID(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+	r := New("test")().ResourcesMap["eventstorecloud_project"]
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+
+	server.InjectFault(clienttest.Fault{Method: http.MethodGet, PathPrefix: "/resources/v1/organizations/", StatusCode: http.StatusForbidden})
+	before := len(server.Requests())
+	diags := r.ReadContext(context.Background(), r.Data(project), meta)
+	if !diags.HasError() {
+		t.Fatal("expected the read to fail")
+	}
+
+	requests := apiRequests(server, before)
+	if len(requests) == 0 {
+		t.Fatal("expected the read to call the API")
+	}
+	expected := "Request ID: " + requests[0].RequestID
+	for _, d := range diags {
+		if !strings.HasSuffix(d.Detail, expected) {
+			t.Errorf("expected the detail of %q to end with %q, got %q", d.Summary, expected, d.Detail)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package esc
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,6 +24,8 @@ func resourceAcl() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"project_id": {
 				Description: "Project ID",
 				Required:    true,
@@ -94,7 +97,7 @@ func resourceAclCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	projectId := d.Get("project_id").(string)
 
 	request := &client.CreateAclRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		CidrBlocks:     translateTfDataToCidrBlocks(d.Get("cidr_blocks")),
 		Name:           d.Get("name").(string),
@@ -102,7 +105,7 @@ func resourceAclCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	resp, err := c.client.AclCreate(ctx, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, aclAPIFields)
 	}
 
 	d.SetId(resp.AclID)
@@ -118,7 +121,7 @@ func resourceAclUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 		AclId := d.Id()
 
 		request := &client.AclUpdateRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			AclID:          AclId,
 			CidrBlocks:     translateTfDataToCidrBlocks(d.Get("cidr_blocks")),
@@ -127,7 +130,7 @@ func resourceAclUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 
 		err := c.client.AclUpdate(ctx, request)
 		if err != nil {
-			return err
+			return apiErrorDiagnostics(err, aclAPIFields)
 		}
 	}
 
@@ -137,20 +140,24 @@ func resourceAclUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 func resourceAclRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	projectId := d.Get("project_id").(string)
 	AclId := d.Id()
 
 	request := &client.GetAclRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		AclID:          AclId,
 	}
 
 	resp, err := c.client.AclGet(ctx, request)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "acl", err)
 	}
 	if resp.Acl.Status == client.StateDeleted {
 		d.SetId("")
@@ -158,13 +165,13 @@ func resourceAclRead(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 
 	if err := d.Set("project_id", resp.Acl.ProjectID); err != nil {
-		diags = append(diags, diag.Errorf("Unable to set project_id", err)...)
+		diags = append(diags, diag.FromErr(fmt.Errorf("Unable to set project_id: %w", err))...)
 	}
 	if err := d.Set("cidr_blocks", translateCidrBlocksToTf(resp.Acl.CidrBlocks)); err != nil {
-		diags = append(diags, diag.Errorf("Unable to set cidr_blocks", err)...)
+		diags = append(diags, diag.FromErr(fmt.Errorf("Unable to set cidr_blocks: %w", err))...)
 	}
 	if err := d.Set("name", resp.Acl.Name); err != nil {
-		diags = append(diags, diag.Errorf("Unable to set name", err)...)
+		diags = append(diags, diag.FromErr(fmt.Errorf("Unable to set name: %w", err))...)
 	}
 
 	return diags
@@ -179,13 +186,13 @@ func resourceAclDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 	AclId := d.Id()
 
 	request := &client.DeleteAclRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		AclID:          AclId,
 	}
 
 	if err := c.client.AclDelete(ctx, request); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return diags
@@ -0,0 +1,82 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestAccAcl(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories(),
+		CheckDestroy:      testAccCheckDestroy(server, "eventstorecloud_acl", testAccAclStatus),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclConfig(server, "Test ACL", `{ address = "192.0.2.0/24", comment = "office" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("eventstorecloud_acl.test", "id"),
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "name", "Test ACL"),
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "cidr_blocks.#", "1"),
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "cidr_blocks.0.address", "192.0.2.0/24"),
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "cidr_blocks.0.comment", "office"),
+				),
+			},
+			{
+				ResourceName:      "eventstorecloud_acl.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateID("eventstorecloud_acl.test", false),
+			},
+			{
+				Config: testAccAclConfig(
+					server,
+					"Renamed ACL",
+					`{ address = "192.0.2.0/24", comment = "office" }`,
+					`{ address = "198.51.100.7/32", comment = "vpn" }`,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "name", "Renamed ACL"),
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "cidr_blocks.#", "2"),
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "cidr_blocks.1.address", "198.51.100.7/32"),
+					resource.TestCheckResourceAttr("eventstorecloud_acl.test", "cidr_blocks.1.comment", "vpn"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAclConfig(server *clienttest.Server, name string, cidrBlocks ...string) string {
+	blocks := ""
+	for _, block := range cidrBlocks {
+		blocks += "\n    " + block + ","
+	}
+
+	return testAccProjectConfig(server, "Test Project") + fmt.Sprintf(`
+resource "eventstorecloud_acl" "test" {
+  project_id  = eventstorecloud_project.test.id
+  name        = %q
+  cidr_blocks = [%s
+  ]
+}
+`, name, blocks)
+}
+
+func testAccAclStatus(ctx context.Context, c *client.Client, rs *terraform.ResourceState) (string, error) {
+	resp, err := c.AclGet(ctx, &client.GetAclRequest{
+		OrganizationID: rs.Primary.Attributes["organization_id"],
+		ProjectID:      rs.Primary.Attributes["project_id"],
+		AclID:          rs.Primary.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Acl.Status, nil
+}
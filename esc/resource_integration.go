@@ -2,6 +2,7 @@ package esc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -25,6 +26,8 @@ func resourceIntegration() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"description": {
 				Description: "Human readable description of the integration",
 				Required:    true,
@@ -118,9 +121,9 @@ func resourceIntegrationCreate(
 		Description: d.Get("description").(string),
 	}
 
-	resp, err := c.client.CreateIntegration(ctx, c.organizationId, projectId, request)
+	resp, err := c.client.CreateIntegration(ctx, c.organizationID(d), projectId, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, integrationAPIFields)
 	}
 
 	d.SetId(resp.Id)
@@ -135,14 +138,18 @@ func resourceIntegrationRead(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	projectId := d.Get("project_id").(string)
 	integrationId := d.Id()
 
-	resp, err := c.client.GetIntegration(ctx, c.organizationId, projectId, integrationId)
+	resp, err := c.client.GetIntegration(ctx, c.organizationID(d), projectId, integrationId)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "integration", err)
 	}
 	if resp.Integration.Status == client.StateDeleted {
 		d.SetId("")
@@ -169,38 +176,50 @@ func resourceIntegrationDelete(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
-	var diags diag.Diagnostics
-
 	projectId := d.Get("project_id").(string)
 	integrationId := d.Id()
 
-	if err := c.client.DeleteIntegration(ctx, c.organizationId, projectId, integrationId); err != nil {
-		return err
+	if err := c.client.DeleteIntegration(ctx, c.organizationID(d), projectId, integrationId); err != nil {
+		return diag.FromErr(err)
 	}
 
-	start := time.Now()
-	for {
-		resp, err := c.client.GetIntegration(ctx, c.organizationId, projectId, integrationId)
-		if err != nil {
-			return diag.Errorf(
-				"error polling integration %q (%q) to see if it actually got deleted",
-				integrationId,
-				d.Get("description"),
-			)
+	return waitForIntegrationDeleted(ctx, c, c.organizationID(d), projectId, integrationId, d.Get("description"))
+}
+
+// Deleting an integration is asynchronous, so poll until the API reports it gone
+func waitForIntegrationDeleted(
+	ctx context.Context,
+	c *providerContext,
+	orgId string,
+	projectId string,
+	integrationId string,
+	description interface{},
+) diag.Diagnostics {
+	err := client.Poll(ctx, &client.PollConfig{
+		Description: fmt.Sprintf("integration %q (%q)", integrationId, description),
+		Target:      []string{client.StateDeleted},
+		MinInterval: time.Second,
+		MaxInterval: 5 * time.Second,
+		Timeout:     30 * time.Second,
+	}, func(ctx context.Context) (string, error) {
+		resp, err := c.client.GetIntegration(ctx, orgId, projectId, integrationId)
+		// The API may drop the integration rather than report it deleted
+		if errors.Is(err, client.ErrNotFound) {
+			return client.StateDeleted, nil
 		}
-		elapsed := time.Since(start)
-		if elapsed.Seconds() > 30.0 {
-			return diag.Errorf(
-				"integration %q (%q) does not seem to be deleting",
+		if err != nil {
+			return "", fmt.Errorf(
+				"error polling integration %q (%q) to see if it actually got deleted: %w",
 				integrationId,
-				d.Get("description"),
+				description,
+				err,
 			)
 		}
-		if resp.Integration.Status == "deleted" {
-			return diags
-		}
-		time.Sleep(1.0)
-	}
+
+		return string(resp.Integration.Status), nil
+	})
+
+	return diag.FromErr(err)
 }
 
 func resourceIntegrationUpdate(
@@ -241,12 +260,12 @@ func resourceIntegrationUpdate(
 		Description: desc,
 	}
 
-	orgId := c.organizationId
+	orgId := c.organizationID(d)
 	projectId := d.Get("project_id").(string)
 	integrationId := d.Id()
 
 	if err := c.client.UpdateIntegration(ctx, orgId, projectId, integrationId, request); err != nil {
-		return err
+		return apiErrorDiagnostics(err, integrationAPIFields)
 	}
 
 	return resourceIntegrationRead(ctx, d, meta)
@@ -3,7 +3,6 @@ package esc
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,6 +26,8 @@ func resourceIntegrationAwsCloudWatchLogs() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"access_key_id": {
 				Description: "The access key ID of IAM credentials which have permissions to create and write to the log group",
 				Required:    false,
@@ -121,9 +122,9 @@ func resourceIntegrationAwsCloudWatchLogsCreate(
 		Description: d.Get("description").(string),
 	}
 
-	resp, err := c.client.CreateIntegration(ctx, c.organizationId, projectId, request)
+	resp, err := c.client.CreateIntegration(ctx, c.organizationID(d), projectId, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, awsCloudWatchIntegrationAPIFields)
 	}
 
 	d.SetId(resp.Id)
@@ -138,14 +139,18 @@ func resourceIntegrationAwsCloudWatchLogsRead(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	projectId := d.Get("project_id").(string)
 	integrationId := d.Id()
 
-	resp, err := c.client.GetIntegration(ctx, c.organizationId, projectId, integrationId)
+	resp, err := c.client.GetIntegration(ctx, c.organizationID(d), projectId, integrationId)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "integration", err)
 	}
 
 	if resp.Integration.Status == client.StateDeleted {
@@ -189,33 +194,11 @@ func resourceIntegrationAwsCloudWatchLogsDelete(
 	projectId := d.Get("project_id").(string)
 	integrationId := d.Id()
 
-	if err := c.client.DeleteIntegration(ctx, c.organizationId, projectId, integrationId); err != nil {
-		return err
+	if err := c.client.DeleteIntegration(ctx, c.organizationID(d), projectId, integrationId); err != nil {
+		return diag.FromErr(err)
 	}
 
-	start := time.Now()
-	for {
-		resp, err := c.client.GetIntegration(ctx, c.organizationId, projectId, integrationId)
-		if err != nil {
-			return diag.Errorf(
-				"error polling integration %q (%q) to see if it actually got deleted",
-				integrationId,
-				d.Get("description"),
-			)
-		}
-		elapsed := time.Since(start)
-		if elapsed.Seconds() > 30.0 {
-			return diag.Errorf(
-				"integration %q (%q) does not seem to be deleting",
-				integrationId,
-				d.Get("description"),
-			)
-		}
-		if resp.Integration.Status == "deleted" {
-			return nil
-		}
-		time.Sleep(1.0)
-	}
+	return waitForIntegrationDeleted(ctx, c, c.organizationID(d), projectId, integrationId, d.Get("description"))
 }
 
 func resourceIntegrationAwsCloudWatchLogsUpdate(
@@ -268,12 +251,12 @@ func resourceIntegrationAwsCloudWatchLogsUpdate(
 		Description: desc,
 	}
 
-	orgId := c.organizationId
+	orgId := c.organizationID(d)
 	projectId := d.Get("project_id").(string)
 	integrationId := d.Id()
 
 	if err := c.client.UpdateIntegration(ctx, orgId, projectId, integrationId, request); err != nil {
-		return err
+		return apiErrorDiagnostics(err, awsCloudWatchIntegrationAPIFields)
 	}
 
 	return resourceIntegrationAwsCloudWatchLogsRead(ctx, d, meta)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,7 +28,15 @@ func resourceManagedCluster() *schema.Resource {
 			StateContext: resourceImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"project_id": {
 				Description: "ID of the project in which the managed cluster exists",
 				Required:    true,
@@ -200,7 +209,7 @@ func resourceManagedClusterCreate(
 	projectId := d.Get("project_id").(string)
 
 	request := &client.CreateManagedClusterRequest{
-		OrganizationID:  c.organizationId,
+		OrganizationID:  c.organizationID(d),
 		ProjectID:       projectId,
 		NetworkId:       d.Get("network_id").(string),
 		Name:            d.Get("name").(string),
@@ -219,23 +228,24 @@ func resourceManagedClusterCreate(
 
 	resp, err := c.client.ManagedClusterCreate(ctx, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, managedClusterAPIFields)
 	}
 
 	d.SetId(resp.ClusterID)
 
 	if err := c.client.ManagedClusterWaitForState(ctx, &client.WaitForManagedClusterStateRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		ClusterID:      resp.ClusterID,
 		State:          "available",
+		Timeout:        d.Timeout(schema.TimeoutCreate),
 	}); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Retrieve initial credentials after cluster is available
 	credentialsResp, credErr := c.client.ManagedClusterGetInitialCredentials(ctx, &client.GetManagedClusterInitialCredentialsRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		ClusterID:      resp.ClusterID,
 	})
@@ -263,20 +273,24 @@ func resourceManagedClusterRead(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	projectId := d.Get("project_id").(string)
 	clusterId := d.Id()
 
 	request := &client.GetManagedClusterRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		ClusterID:      clusterId,
 	}
 
 	resp, err := c.client.ManagedClusterGet(ctx, request)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "managed cluster", err)
 	}
 
 	if resp.ManagedCluster.Status == client.StateDeleted {
@@ -341,7 +355,7 @@ func resourceManagedClusterRead(
 
 	// Attempt to retrieve initial credentials, but don't fail if not available
 	credentialsResp, credErr := c.client.ManagedClusterGetInitialCredentials(ctx, &client.GetManagedClusterInitialCredentialsRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		ClusterID:      clusterId,
 	})
@@ -385,7 +399,7 @@ func resourceManagedClusterUpdate(
 
 	if d.HasChange("name") || d.HasChange("protected") {
 		request := &client.ManagedClusterUpdateRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			ClusterID:      clusterId,
 			Description:    d.Get("name").(string),
@@ -393,28 +407,29 @@ func resourceManagedClusterUpdate(
 		}
 
 		if err := c.client.ManagedClusterUpdate(ctx, request); err != nil {
-			return err
+			return apiErrorDiagnostics(err, managedClusterAPIFields)
 		}
 	}
 
 	if d.HasChange("instance_type") {
 
 		request := &client.ManagedClusterResizeRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			ClusterID:      clusterId,
 			TargetSize:     d.Get("instance_type").(string),
 		}
 		if err := c.client.ManagedClusterResize(ctx, request); err != nil {
-			return err
+			return apiErrorDiagnostics(err, managedClusterAPIFields)
 		}
 		if err := c.client.ManagedClusterWaitForState(ctx, &client.WaitForManagedClusterStateRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			ClusterID:      clusterId,
 			State:          "available",
+			Timeout:        d.Timeout(schema.TimeoutUpdate),
 		}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -435,21 +450,22 @@ func resourceManagedClusterUpdate(
 		}
 
 		request := &client.ManagedClusterUpgradeRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			ClusterID:      clusterId,
 			TargetTag:      serverVersionTag.(string),
 		}
 		if err := c.client.ManagedClusterUpgrade(ctx, request); err != nil {
-			return err
+			return apiErrorDiagnostics(err, managedClusterAPIFields)
 		}
 		if err := c.client.ManagedClusterWaitForState(ctx, &client.WaitForManagedClusterStateRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			ClusterID:      clusterId,
 			State:          "available",
+			Timeout:        d.Timeout(schema.TimeoutUpdate),
 		}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -464,7 +480,7 @@ func resourceManagedClusterUpdate(
 		}
 
 		request := &client.ExpandManagedClusterDiskRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			ClusterID:      clusterId,
 			DiskIops:       int32(d.Get("disk_iops").(int)),
@@ -473,16 +489,17 @@ func resourceManagedClusterUpdate(
 			DiskType:       d.Get("disk_type").(string),
 		}
 		if err := c.client.ManagedClusterExpandDisk(ctx, request); err != nil {
-			return err
+			return apiErrorDiagnostics(err, managedClusterAPIFields)
 		}
 
 		if err := c.client.ManagedClusterWaitForState(ctx, &client.WaitForManagedClusterStateRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			ClusterID:      clusterId,
 			State:          "available",
+			Timeout:        d.Timeout(schema.TimeoutUpdate),
 		}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -500,21 +517,22 @@ func resourceManagedClusterDelete(
 	clusterId := d.Id()
 
 	request := &client.DeleteManagedClusterRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		ClusterID:      clusterId,
 	}
 
 	if err := c.client.ManagedClusterDelete(ctx, request); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return c.client.ManagedClusterWaitForState(ctx, &client.WaitForManagedClusterStateRequest{
-		OrganizationID: c.organizationId,
+	return diag.FromErr(c.client.ManagedClusterWaitForState(ctx, &client.WaitForManagedClusterStateRequest{
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		ClusterID:      clusterId,
 		State:          "deleted",
-	})
+		Timeout:        d.Timeout(schema.TimeoutDelete),
+	}))
 }
 
 func resourceManagedClusterCustomizeDiff(
@@ -0,0 +1,321 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestAccManagedCluster(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories(),
+		CheckDestroy:      testAccCheckDestroy(server, "eventstorecloud_managed_cluster", testAccManagedClusterStatus),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccManagedClusterConfig(server, "Test Cluster", "F1", 10, "GP3", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("eventstorecloud_managed_cluster.test", "id"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "name", "Test Cluster"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "instance_type", "f1"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "disk_type", "gp3"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "disk_size", "10"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "server_version", "23.10"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "server_version_tag", "23.10.0"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "resource_provider", "aws"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "region", "us-west-2"),
+					resource.TestCheckResourceAttrSet("eventstorecloud_managed_cluster.test", "dns_name"),
+					resource.TestCheckResourceAttrSet("eventstorecloud_managed_cluster.test", "initial_admin_password"),
+					resource.TestCheckResourceAttrSet("eventstorecloud_managed_cluster.test", "initial_ops_password"),
+				),
+			},
+			{
+				ResourceName:      "eventstorecloud_managed_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateID("eventstorecloud_managed_cluster.test", false),
+			},
+			{
+				Config: testAccManagedClusterConfig(server, "Renamed Cluster", "C4", 20, "GP3", "23.10.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "name", "Renamed Cluster"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "instance_type", "c4"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "disk_size", "20"),
+					resource.TestCheckResourceAttr("eventstorecloud_managed_cluster.test", "server_version_tag", "23.10.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestManagedClusterCustomizeDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		diskType       string
+		diskIops       int
+		diskThroughput int
+		expectedError  string
+	}{
+		{name: "gp3", diskType: "gp3", diskIops: 3000, diskThroughput: 125},
+		{name: "gp3 in upper case", diskType: "GP3", diskIops: 16000, diskThroughput: 1000},
+		{name: "gp3 without iops", diskType: "GP3", diskThroughput: 125, expectedError: "'iops' must be set when 'type' is 'GP3'"},
+		{name: "gp3 without throughput", diskType: "gp3", diskIops: 3000, expectedError: "'throughput' must be set when 'type' is 'gp3'"},
+		{name: "gp3 with too few iops", diskType: "gp3", diskIops: 2999, diskThroughput: 125, expectedError: "'iops' must be set between 3000 and 16000"},
+		{name: "gp3 with too many iops", diskType: "gp3", diskIops: 16001, diskThroughput: 125, expectedError: "'iops' must be set between 3000 and 16000"},
+		{name: "gp3 with too little throughput", diskType: "gp3", diskIops: 3000, diskThroughput: 124, expectedError: "'throughput' must be set between 125 and 1000"},
+		{name: "gp3 with too much throughput", diskType: "gp3", diskIops: 3000, diskThroughput: 1001, expectedError: "'throughput' must be set between 125 and 1000"},
+		{name: "gp2", diskType: "gp2"},
+		{name: "gp2 with iops", diskType: "gp2", diskIops: 3000, expectedError: "'iops' must not be set when 'type' is 'gp2'"},
+		{name: "ssd with throughput", diskType: "ssd", diskThroughput: 125, expectedError: "'throughput' must not be set when 'type' is 'ssd'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testManagedClusterConfig("project", "network")
+			config["disk_type"] = tt.diskType
+			delete(config, "disk_iops")
+			delete(config, "disk_throughput")
+			if tt.diskIops != 0 {
+				config["disk_iops"] = tt.diskIops
+			}
+			if tt.diskThroughput != 0 {
+				config["disk_throughput"] = tt.diskThroughput
+			}
+
+			_, err := testPlan(nil, "eventstorecloud_managed_cluster", nil, config)
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Fatalf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestManagedClusterCaseNormalization(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+	projectID, networkID := testCreateNetwork(t, meta)
+
+	config := testManagedClusterConfig(projectID, networkID)
+	config["instance_type"] = "F1"
+	config["disk_type"] = "GP3"
+	config["projection_level"] = "System"
+	cluster := mustApply(t, meta, "eventstorecloud_managed_cluster", nil, config)
+
+	for attribute, expected := range map[string]string{
+		"instance_type":    "f1",
+		"disk_type":        "gp3",
+		"projection_level": "system",
+	} {
+		if got := cluster.Attributes[attribute]; got != expected {
+			t.Errorf("expected %s to be stored as %q, got %q", attribute, expected, got)
+		}
+	}
+
+	diff, err := testPlan(meta, "eventstorecloud_managed_cluster", cluster, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no changes for the same configuration, got %v", diff.Attributes)
+	}
+}
+
+// Resizing, upgrading and expanding disks are separate commands, each of
+// which must be waited for before the next one is accepted
+func TestManagedClusterUpdateSequencing(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+	projectID, networkID := testCreateNetwork(t, meta)
+
+	config := testManagedClusterConfig(projectID, networkID)
+	cluster := mustApply(t, meta, "eventstorecloud_managed_cluster", nil, config)
+	before := len(server.Requests())
+
+	config["instance_type"] = "C4"
+	config["server_version_tag"] = "23.10.1"
+	config["disk_size"] = 20
+	config["disk_iops"] = 4000
+	cluster = mustApply(t, meta, "eventstorecloud_managed_cluster", cluster, config)
+
+	clusterPath := path.Join(
+		"/mesdb/v1/organizations", clienttest.DefaultOrganizationID,
+		"projects", projectID,
+		"clusters", cluster.ID,
+	)
+	var calls []string
+	for _, request := range server.Requests()[before:] {
+		switch {
+		case request.Path == clusterPath:
+			calls = append(calls, request.Method)
+		case strings.HasPrefix(request.Path, clusterPath+"/"):
+			calls = append(calls, request.Method+" "+strings.TrimPrefix(request.Path, clusterPath+"/"))
+		}
+	}
+
+	expected := []string{
+		"PUT commands/resize", "GET",
+		"PUT commands/upgrade", "GET",
+		"PUT disk/expand", "GET",
+		"GET", "GET initialCredentials",
+	}
+	if strings.Join(calls, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("expected calls\n%s\ngot\n%s", strings.Join(expected, ", "), strings.Join(calls, ", "))
+	}
+
+	for attribute, expected := range map[string]string{
+		"instance_type":      "c4",
+		"server_version_tag": "23.10.1",
+		"disk_size":          "20",
+		"disk_iops":          "4000",
+	} {
+		if got := cluster.Attributes[attribute]; got != expected {
+			t.Errorf("expected %s to be %q, got %q", attribute, expected, got)
+		}
+	}
+}
+
+func TestManagedClusterUpdateRejected(t *testing.T) {
+	tests := []struct {
+		name          string
+		change        map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:          "upgrade to another version",
+			change:        map[string]interface{}{"server_version_tag": "24.2.0"},
+			expectedError: `tag "24.2.0" must begin with version "23.10"`,
+		},
+		{
+			name:          "smaller disk",
+			change:        map[string]interface{}{"disk_size": 8},
+			expectedError: "Disks cannot be made smaller - must be 10GB or larger.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t)
+			meta := testMeta(t, server)
+			projectID, networkID := testCreateNetwork(t, meta)
+
+			config := testManagedClusterConfig(projectID, networkID)
+			cluster := mustApply(t, meta, "eventstorecloud_managed_cluster", nil, config)
+			before := len(server.Requests())
+
+			for attribute, value := range tt.change {
+				config[attribute] = value
+			}
+			_, diags := testApply(meta, "eventstorecloud_managed_cluster", cluster, config)
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.expectedError) {
+				t.Fatalf("expected error %q, got %+v", tt.expectedError, diags)
+			}
+
+			for _, request := range server.Requests()[before:] {
+				if request.Method != "GET" && !strings.HasPrefix(request.Path, "/oauth/") {
+					t.Errorf("expected no change to be requested, got %s %s", request.Method, request.Path)
+				}
+			}
+		})
+	}
+}
+
+// testCreateNetwork creates a project with an AWS network for resources
+// which need one
+func testCreateNetwork(t *testing.T, meta *providerContext) (string, string) {
+	t.Helper()
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+	network := mustApply(t, meta, "eventstorecloud_network", nil, map[string]interface{}{
+		"project_id":        project.ID,
+		"resource_provider": "aws",
+		"region":            "us-west-2",
+		"cidr_block":        "172.21.0.0/16",
+		"name":              "Test Network",
+	})
+
+	return project.ID, network.ID
+}
+
+func testManagedClusterConfig(projectID string, networkID string) map[string]interface{} {
+	return map[string]interface{}{
+		"project_id":      projectID,
+		"network_id":      networkID,
+		"name":            "Test Cluster",
+		"topology":        "single-node",
+		"instance_type":   "f1",
+		"disk_size":       10,
+		"disk_type":       "gp3",
+		"disk_iops":       3000,
+		"disk_throughput": 125,
+		"server_version":  "23.10",
+	}
+}
+
+// testAccManagedClusterConfig returns a single node cluster in the network of
+// testAccNetworkConfig. The server version tag is left to the API when empty.
+func testAccManagedClusterConfig(
+	server *clienttest.Server,
+	name string,
+	instanceType string,
+	diskSize int,
+	diskType string,
+	serverVersionTag string,
+) string {
+	tag := ""
+	if serverVersionTag != "" {
+		tag = fmt.Sprintf("server_version_tag = %q", serverVersionTag)
+	}
+
+	return testAccNetworkConfig(server, "aws", "Test Network") + fmt.Sprintf(`
+resource "eventstorecloud_managed_cluster" "test" {
+  project_id      = eventstorecloud_project.test.id
+  network_id      = eventstorecloud_network.test.id
+  name            = %q
+  topology        = "single-node"
+  instance_type   = %q
+  disk_size       = %d
+  disk_type       = %q
+  disk_iops       = 3000
+  disk_throughput = 125
+  server_version  = "23.10"
+  %s
+}
+`, name, instanceType, diskSize, diskType, tag)
+}
+
+func testAccManagedClusterStatus(ctx context.Context, c *client.Client, rs *terraform.ResourceState) (string, error) {
+	resp, err := c.ManagedClusterGet(ctx, &client.GetManagedClusterRequest{
+		OrganizationID: rs.Primary.Attributes["organization_id"],
+		ProjectID:      rs.Primary.Attributes["project_id"],
+		ClusterID:      rs.Primary.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ManagedCluster.Status, nil
+}
+
+func TestManagedClusterCreateTimeout(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+	projectID, networkID := testCreateNetwork(t, meta)
+
+	testCreateTimeout(t, server, meta, "eventstorecloud_managed_cluster", testManagedClusterConfig(projectID, networkID))
+}
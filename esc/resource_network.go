@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,7 +26,15 @@ func resourceNetwork() *schema.Resource {
 			StateContext: resourceImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"project_id": {
 				Description: "Project ID",
 				Required:    true,
@@ -87,7 +96,7 @@ func resourceNetworkCreate(
 	projectId := d.Get("project_id").(string)
 
 	request := &client.CreateNetworkRequest{
-		OrganizationID:   c.organizationId,
+		OrganizationID:   c.organizationID(d),
 		ProjectID:        projectId,
 		ResourceProvider: d.Get("resource_provider").(string),
 		CidrBlock:        d.Get("cidr_block").(string),
@@ -105,18 +114,19 @@ func resourceNetworkCreate(
 
 	resp, err := c.client.NetworkCreate(ctx, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, networkAPIFields)
 	}
 
 	d.SetId(resp.NetworkID)
 
 	if err := c.client.NetworkWaitForState(ctx, &client.WaitForNetworkStateRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		NetworkID:      resp.NetworkID,
 		State:          "available",
+		Timeout:        d.Timeout(schema.TimeoutCreate),
 	}); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return resourceNetworkRead(ctx, d, meta)
@@ -134,7 +144,7 @@ func resourceNetworkUpdate(
 		networkId := d.Id()
 
 		request := &client.UpdateNetworkRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      projectId,
 			NetworkID:      networkId,
 			Name:           d.Get("name").(string),
@@ -142,11 +152,11 @@ func resourceNetworkUpdate(
 
 		err := c.client.NetworkUpdate(ctx, request)
 		if err != nil {
-			return err
+			return apiErrorDiagnostics(err, networkAPIFields)
 		}
 	}
 
-	return resourceProjectRead(ctx, d, meta)
+	return resourceNetworkRead(ctx, d, meta)
 }
 
 func resourceNetworkRead(
@@ -156,20 +166,24 @@ func resourceNetworkRead(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	projectId := d.Get("project_id").(string)
 	networkId := d.Id()
 
 	request := &client.GetNetworkRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		NetworkID:      networkId,
 	}
 
 	resp, err := c.client.NetworkGet(ctx, request)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "network", err)
 	}
 	if resp.Network.Status == client.StateDeleted {
 		d.SetId("")
@@ -218,22 +232,23 @@ func resourceNetworkDelete(
 	networkId := d.Id()
 
 	request := &client.DeleteNetworkRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		NetworkID:      networkId,
 	}
 
 	if err := c.client.NetworkDelete(ctx, request); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := c.client.NetworkWaitForState(ctx, &client.WaitForNetworkStateRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		NetworkID:      networkId,
 		State:          "deleted",
+		Timeout:        d.Timeout(schema.TimeoutDelete),
 	}); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return diags
@@ -0,0 +1,161 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestAccNetwork(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories(),
+		CheckDestroy:      testAccCheckDestroy(server, "eventstorecloud_network", testAccNetworkStatus),
+		Steps: []resource.TestStep{
+			{
+				// The provider is given in upper case to check that it is
+				// normalized rather than showing as a change on every plan
+				Config: testAccNetworkConfig(server, "AWS", "Test Network"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("eventstorecloud_network.test", "id"),
+					resource.TestCheckResourceAttrPair("eventstorecloud_network.test", "project_id", "eventstorecloud_project.test", "id"),
+					resource.TestCheckResourceAttr("eventstorecloud_network.test", "resource_provider", "aws"),
+					resource.TestCheckResourceAttr("eventstorecloud_network.test", "region", "us-west-2"),
+					resource.TestCheckResourceAttr("eventstorecloud_network.test", "cidr_block", "172.21.0.0/16"),
+					resource.TestCheckResourceAttr("eventstorecloud_network.test", "name", "Test Network"),
+				),
+			},
+			{
+				ResourceName:      "eventstorecloud_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateID("eventstorecloud_network.test", false),
+			},
+			{
+				ResourceName:      "eventstorecloud_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateID("eventstorecloud_network.test", true),
+			},
+			{
+				Config: testAccNetworkConfig(server, "AWS", "Renamed Network"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("eventstorecloud_network.test", "name", "Renamed Network"),
+					resource.TestCheckResourceAttr("eventstorecloud_network.test", "resource_provider", "aws"),
+				),
+			},
+		},
+	})
+}
+
+// Renaming a network used to read the network back as if it were a project,
+// which found nothing and dropped the network from the state
+func TestNetworkUpdateReadsNetwork(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+	config := map[string]interface{}{
+		"project_id":        project.ID,
+		"resource_provider": "aws",
+		"region":            "us-west-2",
+		"cidr_block":        "172.21.0.0/16",
+		"name":              "Test Network",
+	}
+	network := mustApply(t, meta, "eventstorecloud_network", nil, config)
+
+	config["name"] = "Renamed Network"
+	updated := mustApply(t, meta, "eventstorecloud_network", network, config)
+
+	if updated.ID != network.ID {
+		t.Errorf("expected network %s to be kept, got %s", network.ID, updated.ID)
+	}
+	if got := updated.Attributes["name"]; got != "Renamed Network" {
+		t.Errorf("expected the new name to be read back, got %q", got)
+	}
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request.Path, "/resources/v1/") && strings.HasSuffix(request.Path, "/projects/"+network.ID) {
+			t.Errorf("network %s was looked up as a project: %s %s", network.ID, request.Method, request.Path)
+		}
+	}
+}
+
+func TestNetworkCaseNormalization(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+	config := map[string]interface{}{
+		"project_id":        project.ID,
+		"resource_provider": "GCP",
+		"region":            "us-central1",
+		"cidr_block":        "172.21.0.0/16",
+		"name":              "Test Network",
+	}
+	network := mustApply(t, meta, "eventstorecloud_network", nil, config)
+
+	if got := network.Attributes["resource_provider"]; got != "gcp" {
+		t.Errorf("expected resource_provider to be stored as gcp, got %q", got)
+	}
+
+	diff, err := testPlan(meta, "eventstorecloud_network", network, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no changes for the same configuration, got %v", diff.Attributes)
+	}
+}
+
+func testAccNetworkConfig(server *clienttest.Server, provider string, name string) string {
+	return testAccProjectConfig(server, "Test Project") + fmt.Sprintf(`
+resource "eventstorecloud_network" "test" {
+  project_id        = eventstorecloud_project.test.id
+  resource_provider = %q
+  region            = "us-west-2"
+  cidr_block        = "172.21.0.0/16"
+  name              = %q
+}
+`, provider, name)
+}
+
+func testAccNetworkStatus(ctx context.Context, c *client.Client, rs *terraform.ResourceState) (string, error) {
+	resp, err := c.NetworkGet(ctx, &client.GetNetworkRequest{
+		OrganizationID: rs.Primary.Attributes["organization_id"],
+		ProjectID:      rs.Primary.Attributes["project_id"],
+		NetworkID:      rs.Primary.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Network.Status, nil
+}
+
+func TestNetworkCreateTimeout(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+
+	project := mustApply(t, meta, "eventstorecloud_project", nil, map[string]interface{}{
+		"name": "Test Project",
+	})
+
+	testCreateTimeout(t, server, meta, "eventstorecloud_network", map[string]interface{}{
+		"project_id":        project.ID,
+		"resource_provider": "aws",
+		"region":            "us-west-2",
+		"cidr_block":        "172.21.0.0/16",
+		"name":              "Test Network",
+	})
+}
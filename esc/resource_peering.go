@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -26,8 +27,16 @@ func resourcePeering() *schema.Resource {
 			StateContext: resourceImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"project_id": {
 				Description: "Project ID",
 				Required:    true,
@@ -196,7 +205,7 @@ func resourcePeeringCreate(
 	}
 
 	request := &client.CreatePeeringRequest{
-		OrganizationID:        c.organizationId,
+		OrganizationID:        c.organizationID(d),
 		ProjectID:             projectId,
 		NetworkId:             d.Get("network_id").(string),
 		Name:                  d.Get("name").(string),
@@ -208,19 +217,20 @@ func resourcePeeringCreate(
 
 	resp, err := c.client.PeeringCreate(ctx, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, peeringAPIFields)
 	}
 
 	d.SetId(resp.PeeringID)
 
 	peering, err := c.client.PeeringWaitForState(ctx, &client.WaitForPeeringStateRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		PeeringID:      resp.PeeringID,
 		State:          "initiated",
+		Timeout:        d.Timeout(schema.TimeoutCreate),
 	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := resourcePeeringSetProviderMetadata(d, peering.Provider, peering.ProviderPeeringMetadata); err != nil {
@@ -239,14 +249,14 @@ func resourcePeeringUpdate(
 
 	if d.HasChange("name") {
 		request := &client.UpdatePeeringRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      d.Get("project_id").(string),
 			PeeringID:      d.Id(),
 			Name:           d.Get("name").(string),
 		}
 
 		if err := c.client.PeeringUpdate(ctx, request); err != nil {
-			return err
+			return apiErrorDiagnostics(err, peeringAPIFields)
 		}
 	}
 
@@ -277,20 +287,24 @@ func resourcePeeringReadWithCheck(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	projectId := d.Get("project_id").(string)
 	peeringId := d.Id()
 
 	request := &client.GetPeeringRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		PeeringID:      peeringId,
 	}
 
 	resp, err := c.client.PeeringGet(ctx, request)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "peering", err)
 	}
 	if resp.Peering.Status == client.StateDeleted {
 		d.SetId("")
@@ -353,25 +367,29 @@ func resourcePeeringDelete(
 	peeringId := d.Id()
 
 	request := &client.DeletePeeringRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		PeeringID:      peeringId,
 	}
 
 	if err := c.client.PeeringDelete(ctx, request); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	peering, err := c.client.PeeringWaitForState(ctx, &client.WaitForPeeringStateRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      projectId,
 		PeeringID:      peeringId,
 		State:          "deleted",
+		Timeout:        d.Timeout(schema.TimeoutDelete),
 	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	if peering.Status != "deleted" {
 		return diag.Errorf("Peering wait for status returned, but the state is still not correct")
 	}
-	return err
+	return nil
 }
 
 func Warnof(format string, a ...interface{}) diag.Diagnostics {
@@ -0,0 +1,139 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestAccPeering(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories(),
+		CheckDestroy:      testAccCheckDestroy(server, "eventstorecloud_peering", testAccPeeringStatus),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPeeringConfig(server, "Test Peering"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("eventstorecloud_peering.test", "id"),
+					resource.TestCheckResourceAttrPair("eventstorecloud_peering.test", "network_id", "eventstorecloud_network.test", "id"),
+					resource.TestCheckResourceAttr("eventstorecloud_peering.test", "name", "Test Peering"),
+					resource.TestCheckResourceAttr("eventstorecloud_peering.test", "peer_resource_provider", "aws"),
+					resource.TestCheckResourceAttr("eventstorecloud_peering.test", "routes.#", "1"),
+					resource.TestCheckTypeSetElemAttr("eventstorecloud_peering.test", "routes.*", "10.0.0.0/16"),
+					resource.TestCheckResourceAttrSet("eventstorecloud_peering.test", "provider_metadata.aws_peering_link_id"),
+				),
+			},
+			{
+				ResourceName:      "eventstorecloud_peering.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateID("eventstorecloud_peering.test", false),
+			},
+			{
+				Config: testAccPeeringConfig(server, "Renamed Peering"),
+				Check:  resource.TestCheckResourceAttr("eventstorecloud_peering.test", "name", "Renamed Peering"),
+			},
+		},
+	})
+}
+
+func TestUpgrade1_5_6(t *testing.T) {
+	metadata := map[string]interface{}{"aws_peering_link_id": "pcx-1234"}
+
+	tests := []struct {
+		name     string
+		state    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "set of one map",
+			state:    map[string]interface{}{"name": "peering", "provider_metadata": []interface{}{metadata}},
+			expected: map[string]interface{}{"name": "peering", "provider_metadata": metadata},
+		},
+		{
+			name:     "empty set",
+			state:    map[string]interface{}{"name": "peering", "provider_metadata": []interface{}{}},
+			expected: map[string]interface{}{"name": "peering", "provider_metadata": map[string]interface{}{}},
+		},
+		{
+			name:     "set of several maps",
+			state:    map[string]interface{}{"name": "peering", "provider_metadata": []interface{}{metadata, metadata}},
+			expected: map[string]interface{}{"name": "peering", "provider_metadata": map[string]interface{}{}},
+		},
+		{
+			name:     "map",
+			state:    map[string]interface{}{"name": "peering", "provider_metadata": metadata},
+			expected: map[string]interface{}{"name": "peering", "provider_metadata": metadata},
+		},
+		{
+			name:     "no metadata",
+			state:    map[string]interface{}{"name": "peering"},
+			expected: map[string]interface{}{"name": "peering"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgraded, err := upgrade1_5_6(context.Background(), tt.state, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(upgraded, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, upgraded)
+			}
+		})
+	}
+}
+
+func testAccPeeringConfig(server *clienttest.Server, name string) string {
+	return testAccNetworkConfig(server, "aws", "Test Network") + fmt.Sprintf(`
+resource "eventstorecloud_peering" "test" {
+  project_id             = eventstorecloud_project.test.id
+  network_id             = eventstorecloud_network.test.id
+  name                   = %q
+  peer_resource_provider = eventstorecloud_network.test.resource_provider
+  peer_network_region    = eventstorecloud_network.test.region
+  peer_account_id        = "123456789012"
+  peer_network_id        = "vpc-0123456789abcdef0"
+  routes                 = ["10.0.0.0/16"]
+}
+`, name)
+}
+
+func testAccPeeringStatus(ctx context.Context, c *client.Client, rs *terraform.ResourceState) (string, error) {
+	resp, err := c.PeeringGet(ctx, &client.GetPeeringRequest{
+		OrganizationID: rs.Primary.Attributes["organization_id"],
+		ProjectID:      rs.Primary.Attributes["project_id"],
+		PeeringID:      rs.Primary.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Peering.Status, nil
+}
+
+func TestPeeringCreateTimeout(t *testing.T) {
+	server := newTestServer(t)
+	meta := testMeta(t, server)
+	projectID, networkID := testCreateNetwork(t, meta)
+
+	testCreateTimeout(t, server, meta, "eventstorecloud_peering", map[string]interface{}{
+		"project_id":             projectID,
+		"network_id":             networkID,
+		"name":                   "Test Peering",
+		"peer_resource_provider": "aws",
+		"peer_network_region":    "us-west-2",
+		"peer_account_id":        "123456789012",
+		"peer_network_id":        "vpc-0123456789abcdef0",
+		"routes":                 []interface{}{"10.0.0.0/16"},
+	})
+}
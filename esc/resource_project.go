@@ -19,10 +19,12 @@ func resourceProject() *schema.Resource {
 		DeleteContext: resourceProjectDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceProjectImport,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"name": {
 				Description: "Human-friendly name for the project",
 				Type:        schema.TypeString,
@@ -40,13 +42,13 @@ func resourceProjectCreate(
 	c := meta.(*providerContext)
 
 	request := &client.CreateProjectRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		Name:           d.Get("name").(string),
 	}
 
 	resp, err := c.client.ProjectCreate(ctx, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, projectAPIFields)
 	}
 
 	d.SetId(resp.ProjectID)
@@ -61,16 +63,20 @@ func resourceProjectRead(
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
 
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var diags diag.Diagnostics
 
 	request := &client.GetProjectRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      d.Id(),
 	}
 
 	resp, err := c.client.ProjectGet(ctx, request)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "project", err)
 	}
 
 	if err := d.Set("name", resp.Project.Name); err != nil {
@@ -89,13 +95,13 @@ func resourceProjectUpdate(
 
 	if d.HasChange("name") {
 		request := &client.UpdateProjectRequest{
-			OrganizationID: c.organizationId,
+			OrganizationID: c.organizationID(d),
 			ProjectID:      d.Id(),
 			Name:           d.Get("name").(string),
 		}
 
 		if err := c.client.ProjectUpdate(ctx, request); err != nil {
-			return err
+			return apiErrorDiagnostics(err, projectAPIFields)
 		}
 	}
 
@@ -110,9 +116,9 @@ func resourceProjectDelete(
 	c := meta.(*providerContext)
 
 	request := &client.DeleteProjectRequest{
-		OrganizationID: c.organizationId,
+		OrganizationID: c.organizationID(d),
 		ProjectID:      d.Id(),
 	}
 
-	return c.client.ProjectDelete(ctx, request)
+	return diag.FromErr(c.client.ProjectDelete(ctx, request))
 }
@@ -0,0 +1,68 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestAccProject(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories(),
+		CheckDestroy:      testAccCheckDestroy(server, "eventstorecloud_project", testAccProjectStatus),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectConfig(server, "Test Project"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("eventstorecloud_project.test", "id"),
+					resource.TestCheckResourceAttr("eventstorecloud_project.test", "name", "Test Project"),
+					resource.TestCheckResourceAttr("eventstorecloud_project.test", "organization_id", clienttest.DefaultOrganizationID),
+				),
+			},
+			{
+				ResourceName:      "eventstorecloud_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "eventstorecloud_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["eventstorecloud_project.test"]
+					return rs.Primary.Attributes["organization_id"] + ":" + rs.Primary.ID, nil
+				},
+			},
+			{
+				Config: testAccProjectConfig(server, "Renamed Project"),
+				Check:  resource.TestCheckResourceAttr("eventstorecloud_project.test", "name", "Renamed Project"),
+			},
+		},
+	})
+}
+
+func testAccProjectConfig(server *clienttest.Server, name string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "eventstorecloud_project" "test" {
+  name = %q
+}
+`, name)
+}
+
+func testAccProjectStatus(ctx context.Context, c *client.Client, rs *terraform.ResourceState) (string, error) {
+	if _, err := c.ProjectGet(ctx, &client.GetProjectRequest{
+		OrganizationID: rs.Primary.Attributes["organization_id"],
+		ProjectID:      rs.Primary.ID,
+	}); err != nil {
+		return "", err
+	}
+	return "", nil
+}
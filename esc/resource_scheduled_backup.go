@@ -2,6 +2,8 @@ package esc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -23,6 +25,8 @@ func resourceScheduledBackup() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"organization_id": organizationIDSchema(),
+
 			"description": {
 				Description: "Human readable description of the job",
 				Required:    true,
@@ -83,9 +87,9 @@ func resourceScheduledBackupCreate(
 		Type:        "ScheduledBackup",
 	}
 
-	resp, err := c.client.CreateJob(ctx, c.organizationId, projectId, request)
+	resp, err := c.client.CreateJob(ctx, c.organizationID(d), projectId, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, scheduledBackupAPIFields)
 	}
 
 	d.SetId(resp.Id)
@@ -99,14 +103,18 @@ func resourceScheduledBackupRead(
 	meta interface{},
 ) diag.Diagnostics {
 	c := meta.(*providerContext)
+
+	if err := d.Set("organization_id", c.organizationID(d)); err != nil {
+		return diag.FromErr(err)
+	}
 	projectId := d.Get("project_id").(string)
 	jobId := d.Id()
 
 	var diags diag.Diagnostics
 
-	resp, err := c.client.GetJob(ctx, c.organizationId, projectId, jobId)
+	resp, err := c.client.GetJob(ctx, c.organizationID(d), projectId, jobId)
 	if err != nil {
-		return diag.Errorf("Internal Server Error, try again later")
+		return handleReadError(ctx, d, "scheduled backup", err)
 	}
 
 	if resp.Job.Status == client.StateDeleted {
@@ -145,31 +153,33 @@ func resourceScheduledBackupDelete(
 	projectId := d.Get("project_id").(string)
 	jobId := d.Id()
 
-	if err := c.client.DeleteJob(ctx, c.organizationId, projectId, jobId); err != nil {
-		return err
+	if err := c.client.DeleteJob(ctx, c.organizationID(d), projectId, jobId); err != nil {
+		return diag.FromErr(err)
 	}
 
-	start := time.Now()
-	for {
-		resp, err := c.client.GetJob(ctx, c.organizationId, projectId, jobId)
-		if err != nil {
-			return diag.Errorf(
-				"error polling job %q (%q) to see if it actually got deleted",
-				jobId,
-				d.Get("description"),
-			)
+	err := client.Poll(ctx, &client.PollConfig{
+		Description: fmt.Sprintf("job %q (%q)", jobId, d.Get("description")),
+		Target:      []string{client.StateDeleted},
+		MinInterval: time.Second,
+		MaxInterval: 5 * time.Second,
+		Timeout:     30 * time.Second,
+	}, func(ctx context.Context) (string, error) {
+		resp, err := c.client.GetJob(ctx, c.organizationID(d), projectId, jobId)
+		// The API may drop the job rather than report it deleted
+		if errors.Is(err, client.ErrNotFound) {
+			return client.StateDeleted, nil
 		}
-		elapsed := time.Since(start)
-		if elapsed.Seconds() > 30.0 {
-			return diag.Errorf(
-				"job %q (%q) does not seem to be deleting",
+		if err != nil {
+			return "", fmt.Errorf(
+				"error polling job %q (%q) to see if it actually got deleted: %w",
 				jobId,
 				d.Get("description"),
+				err,
 			)
 		}
-		if resp.Job.Status == "deleted" {
-			return nil
-		}
-		time.Sleep(1.0)
-	}
+
+		return resp.Job.Status, nil
+	})
+
+	return diag.FromErr(err)
 }
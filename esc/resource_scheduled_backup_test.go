@@ -0,0 +1,99 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/EventStore/terraform-provider-eventstorecloud/client"
+	"github.com/EventStore/terraform-provider-eventstorecloud/client/clienttest"
+)
+
+func TestAccScheduledBackup(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories(),
+		CheckDestroy:      testAccCheckDestroy(server, "eventstorecloud_scheduled_backup", testAccScheduledBackupStatus),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScheduledBackupConfig(server, "0 */12 * * *", 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("eventstorecloud_scheduled_backup.test", "id"),
+					resource.TestCheckResourceAttrPair("eventstorecloud_scheduled_backup.test", "source_cluster_id", "eventstorecloud_managed_cluster.test", "id"),
+					resource.TestCheckResourceAttr("eventstorecloud_scheduled_backup.test", "description", "Test Backups"),
+					resource.TestCheckResourceAttr("eventstorecloud_scheduled_backup.test", "schedule", "0 */12 * * *"),
+					resource.TestCheckResourceAttr("eventstorecloud_scheduled_backup.test", "backup_description", "{cluster} at {datetime}"),
+					resource.TestCheckResourceAttr("eventstorecloud_scheduled_backup.test", "max_backup_count", "3"),
+				),
+			},
+			{
+				ResourceName:      "eventstorecloud_scheduled_backup.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateID("eventstorecloud_scheduled_backup.test", false),
+			},
+			{
+				// Jobs can't be changed, so this replaces the backup
+				Config: testAccScheduledBackupConfig(server, "0 0 * * *", 7),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("eventstorecloud_scheduled_backup.test", "schedule", "0 0 * * *"),
+					resource.TestCheckResourceAttr("eventstorecloud_scheduled_backup.test", "max_backup_count", "7"),
+				),
+			},
+		},
+	})
+}
+
+func testAccScheduledBackupConfig(server *clienttest.Server, schedule string, maxBackupCount int) string {
+	return testAccManagedClusterConfig(server, "Test Cluster", "F1", 10, "GP3", "") + fmt.Sprintf(`
+resource "eventstorecloud_scheduled_backup" "test" {
+  project_id         = eventstorecloud_project.test.id
+  source_cluster_id  = eventstorecloud_managed_cluster.test.id
+  description        = "Test Backups"
+  schedule           = %q
+  backup_description = "{cluster} at {datetime}"
+  max_backup_count   = %d
+}
+`, schedule, maxBackupCount)
+}
+
+func testAccScheduledBackupStatus(ctx context.Context, c *client.Client, rs *terraform.ResourceState) (string, error) {
+	resp, err := c.GetJob(ctx, rs.Primary.Attributes["organization_id"], rs.Primary.Attributes["project_id"], rs.Primary.ID)
+	if err != nil {
+		return "", err
+	}
+	return resp.Job.Status, nil
+}
+
+// Once deleted, the API may drop a job instead of reporting it deleted,
+// which the wait for its deletion must accept
+func TestScheduledBackupDeleteNotFound(t *testing.T) {
+	ctx := context.Background()
+	server := clienttest.NewServer(&clienttest.Config{RemoveDeleted: true})
+	t.Cleanup(server.Close)
+	meta := testMeta(t, server)
+	r := New("test")().ResourcesMap["eventstorecloud_scheduled_backup"]
+
+	projectID, networkID := testCreateNetwork(t, meta)
+	cluster := mustApply(t, meta, "eventstorecloud_managed_cluster", nil, testManagedClusterConfig(projectID, networkID))
+	backup := mustApply(t, meta, "eventstorecloud_scheduled_backup", nil, map[string]interface{}{
+		"project_id":         projectID,
+		"source_cluster_id":  cluster.ID,
+		"description":        "Test Backups",
+		"schedule":           "0 */12 * * *",
+		"backup_description": "{cluster} at {datetime}",
+		"max_backup_count":   3,
+	})
+
+	before := len(server.Requests())
+	if diags := r.DeleteContext(ctx, r.Data(backup), meta); diags.HasError() {
+		t.Fatalf("deleting scheduled backup: %+v", diags)
+	}
+	if polls := countPolls(server, before, backup.ID); polls != 2 {
+		t.Errorf("expected the job to be seen deleting, then not found, got %d polls", polls)
+	}
+}
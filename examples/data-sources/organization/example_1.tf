@@ -0,0 +1,5 @@
+data "eventstorecloud_organization" "current" {}
+
+output "organization" {
+  value = "${data.eventstorecloud_organization.current.name} (${data.eventstorecloud_organization.current.id})"
+}
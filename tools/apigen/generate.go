@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// generator accumulates the declarations of the generated file
+type generator struct {
+	spec    *spec
+	body    bytes.Buffer
+	imports map[string]bool
+}
+
+func generate(s *spec, specPath string, packageName string) ([]byte, error) {
+	g := &generator{
+		spec:    s,
+		imports: map[string]bool{},
+	}
+
+	if err := s.Components.Schemas.each(g.schema); err != nil {
+		return nil, err
+	}
+
+	if len(s.Paths.keys) > 0 {
+		g.imports["context"] = true
+		g.imports["net/http"] = true
+	}
+	if err := s.Paths.each(g.pathItem); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by apigen from %s. DO NOT EDIT.\n\n", filepath.Base(specPath))
+	fmt.Fprintf(&out, "package %s\n\n", packageName)
+
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	if len(imports) > 0 {
+		out.WriteString("import (\n")
+		for _, path := range imports {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(g.body.Bytes())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %w\n%s", err, out.Bytes())
+	}
+	return source, nil
+}
+
+func (g *generator) schema(name string, s *schema) error {
+	g.body.WriteString("\n")
+	g.comment("", s.Description)
+
+	if len(s.Enum) > 0 {
+		return g.enum(name, s)
+	}
+
+	values, isMap, err := s.additional()
+	if err != nil {
+		return fmt.Errorf("schema %s: %w", name, err)
+	}
+	if s.Type != "object" || isMap && len(s.Properties.keys) == 0 {
+		goType, err := g.goType(s)
+		if err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+		fmt.Fprintf(&g.body, "type %s %s\n", name, goType)
+		return nil
+	}
+	if values != nil || isMap {
+		return fmt.Errorf("schema %s: objects with both properties and additionalProperties are not supported", name)
+	}
+
+	fmt.Fprintf(&g.body, "type %s struct {\n", name)
+	err = s.Properties.each(func(property string, p *schema) error {
+		goType, err := g.goType(p)
+		if err != nil {
+			return fmt.Errorf("schema %s, property %s: %w", name, property, err)
+		}
+
+		tag := property
+		if !s.isRequired(property) {
+			tag += ",omitempty"
+			if !p.SkipOptionalPointer {
+				goType = "*" + goType
+			}
+		}
+
+		fieldName := p.GoName
+		if fieldName == "" {
+			fieldName = exportedName(property)
+		}
+
+		g.comment("\t", p.Description)
+		fmt.Fprintf(&g.body, "\t%s %s `json:%q`\n", fieldName, goType, tag)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	g.body.WriteString("}\n")
+
+	return nil
+}
+
+func (g *generator) enum(name string, s *schema) error {
+	if s.Type != "string" {
+		return fmt.Errorf("schema %s: only string enums are supported", name)
+	}
+	if len(s.EnumVarNames) > 0 && len(s.EnumVarNames) != len(s.Enum) {
+		return fmt.Errorf("schema %s: x-enum-varnames must name every value", name)
+	}
+
+	fmt.Fprintf(&g.body, "type %s string\n\n", name)
+	fmt.Fprintf(&g.body, "// Values of %s\n", name)
+	g.body.WriteString("const (\n")
+	for i, value := range s.Enum {
+		varName := name + exportedName(value)
+		if len(s.EnumVarNames) > 0 {
+			varName = s.EnumVarNames[i]
+		}
+		fmt.Fprintf(&g.body, "\t%s %s = %q\n", varName, name, value)
+	}
+	g.body.WriteString(")\n")
+
+	return nil
+}
+
+// goType returns the Go type of values of a schema used in a field, an item
+// or a named type
+func (g *generator) goType(s *schema) (string, error) {
+	if s.Ref != "" {
+		return refName(s.Ref)
+	}
+
+	if s.GoType != "" {
+		if pkg, _, found := strings.Cut(s.GoType, "."); found {
+			g.imports[strings.TrimLeft(pkg, "*[]")] = true
+		}
+		return s.GoType, nil
+	}
+
+	switch s.Type {
+	case "string":
+		if len(s.Enum) > 0 {
+			return "", fmt.Errorf("inline enums are not supported, declare them in components")
+		}
+		if s.Format == "date-time" {
+			g.imports["time"] = true
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		switch s.Format {
+		case "int32":
+			return "int32", nil
+		case "int64":
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		if s.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("arrays need items")
+		}
+		item, err := g.goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if len(s.Properties.keys) > 0 {
+			return "", fmt.Errorf("inline objects are not supported, declare them in components")
+		}
+		values, _, err := s.additional()
+		if err != nil {
+			return "", err
+		}
+		if values == nil {
+			return "map[string]interface{}", nil
+		}
+		value, err := g.goType(values)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + value, nil
+	}
+
+	return "", fmt.Errorf("unsupported type %q", s.Type)
+}
+
+func (g *generator) pathItem(path string, item pathItem) error {
+	operations := []struct {
+		method    string
+		operation *operation
+	}{
+		{"Get", item.Get},
+		{"Post", item.Post},
+		{"Put", item.Put},
+		{"Patch", item.Patch},
+		{"Delete", item.Delete},
+	}
+
+	for _, o := range operations {
+		if o.operation == nil {
+			continue
+		}
+		if err := g.operation(o.method, path, o.operation); err != nil {
+			return fmt.Errorf("%s %s: %w", strings.ToUpper(o.method), path, err)
+		}
+	}
+	return nil
+}
+
+var pathParameterPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+func (g *generator) operation(method string, path string, o *operation) error {
+	if o.OperationID == "" {
+		return fmt.Errorf("operationId is required")
+	}
+	if o.Activity == "" {
+		return fmt.Errorf("x-activity is required")
+	}
+	name := exportedName(o.OperationID)
+
+	parameters := map[string]bool{}
+	for _, p := range o.Parameters {
+		if p.In != "path" {
+			return fmt.Errorf("parameter %s: only path parameters are supported", p.Name)
+		}
+		if p.Schema == nil || p.Schema.Type != "string" {
+			return fmt.Errorf("parameter %s: only string parameters are supported", p.Name)
+		}
+		parameters[p.Name] = true
+	}
+
+	// The path is built from its literal parts and escaped parameters, in
+	// the order they appear in
+	arguments := []string{"ctx context.Context"}
+	var pathParts []string
+	last := 0
+	for _, match := range pathParameterPattern.FindAllStringSubmatchIndex(path, -1) {
+		parameter := path[match[2]:match[3]]
+		if !parameters[parameter] {
+			return fmt.Errorf("path parameter %s is not declared", parameter)
+		}
+		delete(parameters, parameter)
+
+		pathParts = append(pathParts, fmt.Sprintf("%q", path[last:match[0]]), fmt.Sprintf("url.PathEscape(%s)", parameter))
+		arguments = append(arguments, parameter+" string")
+		last = match[1]
+	}
+	if last < len(path) {
+		pathParts = append(pathParts, fmt.Sprintf("%q", path[last:]))
+	}
+	if len(parameters) > 0 {
+		var unused []string
+		for parameter := range parameters {
+			unused = append(unused, parameter)
+		}
+		sort.Strings(unused)
+		return fmt.Errorf("parameters %s are not in the path", strings.Join(unused, ", "))
+	}
+	if len(pathParts) > 1 {
+		g.imports["net/url"] = true
+	}
+
+	var bodyType string
+	if o.RequestBody != nil {
+		var err error
+		bodyType, err = jsonContentType(o.RequestBody.Content)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		arguments = append(arguments, "body *"+bodyType)
+	}
+
+	var resultType string
+	var codes []string
+	codes = append(codes, o.Responses.keys...)
+	sort.Strings(codes)
+	for _, code := range codes {
+		r := o.Responses.values[code]
+		if !strings.HasPrefix(code, "2") || len(r.Content) == 0 {
+			continue
+		}
+		var err error
+		resultType, err = jsonContentType(r.Content)
+		if err != nil {
+			return fmt.Errorf("response %s: %w", code, err)
+		}
+		break
+	}
+
+	g.body.WriteString("\n")
+	if o.Summary != "" {
+		g.comment("", name+" "+lowerFirst(o.Summary))
+		g.body.WriteString("//\n")
+	}
+	fmt.Fprintf(&g.body, "// %s %s\n", strings.ToUpper(method), path)
+
+	request := fmt.Sprintf("&Request{\n\t\tMethod: http.Method%s,\n\t\tPath: %s,\n", method, strings.Join(pathParts, " + "))
+	if bodyType != "" {
+		request += "\t\tBody: body,\n"
+	}
+	request += fmt.Sprintf("\t\tActivity: %q,\n\t}", o.Activity)
+
+	if resultType == "" {
+		fmt.Fprintf(&g.body, "func (c *Client) %s(%s) error {\n", name, strings.Join(arguments, ", "))
+		fmt.Fprintf(&g.body, "\treturn c.doer.Do(ctx, %s, nil)\n}\n", request)
+		return nil
+	}
+
+	fmt.Fprintf(&g.body, "func (c *Client) %s(%s) (*%s, error) {\n", name, strings.Join(arguments, ", "), resultType)
+	fmt.Fprintf(&g.body, "\tvar result %s\n", resultType)
+	fmt.Fprintf(&g.body, "\tif err := c.doer.Do(ctx, %s, &result); err != nil {\n\t\treturn nil, err\n\t}\n", request)
+	g.body.WriteString("\treturn &result, nil\n}\n")
+	return nil
+}
+
+// jsonContentType returns the schema named by a JSON body
+func jsonContentType(content map[string]mediaType) (string, error) {
+	m, ok := content["application/json"]
+	if !ok || len(content) != 1 {
+		return "", fmt.Errorf("only application/json content is supported")
+	}
+	if m.Schema == nil || m.Schema.Ref == "" {
+		return "", fmt.Errorf("bodies must refer to a schema in components")
+	}
+	return refName(m.Schema.Ref)
+}
+
+// comment writes text as a comment, wrapped at about 80 columns
+func (g *generator) comment(indent string, text string) {
+	if text == "" {
+		return
+	}
+
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(indent)+3+len(line)+1+len(word) > 80 {
+			fmt.Fprintf(&g.body, "%s// %s\n", indent, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	fmt.Fprintf(&g.body, "%s// %s\n", indent, line)
+}
+
+// exportedName turns a JSON name such as diskSizeGb or three-node-multi-zone
+// into a Go one such as DiskSizeGb or ThreeNodeMultiZone
+func exportedName(name string) string {
+	var result strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+func lowerFirst(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToLower(text[:1]) + text[1:]
+}
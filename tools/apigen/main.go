@@ -0,0 +1,50 @@
+// Command apigen generates the Event Store Cloud API client in client/escapi
+// from its OpenAPI description.
+//
+// It understands the subset of OpenAPI 3 used by the description: JSON
+// request and response bodies referring to component schemas, path
+// parameters, and schemas made of objects, arrays, maps, enums and scalars.
+// It fails on anything else rather than generating a client which silently
+// misses part of the API. The x-go-name, x-go-type,
+// x-go-type-skip-optional-pointer and x-enum-varnames extensions have the
+// same meaning as for oapi-codegen, and x-activity describes an operation in
+// error messages.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	specPath := flag.String("spec", "openapi.json", "OpenAPI description to generate the client from")
+	outPath := flag.String("out", "escapi.gen.go", "generated Go file")
+	packageName := flag.String("package", "escapi", "package of the generated file")
+	flag.Parse()
+
+	if err := run(*specPath, *outPath, *packageName); err != nil {
+		fmt.Fprintf(os.Stderr, "apigen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath string, outPath string, packageName string) error {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("error parsing %s: %w", specPath, err)
+	}
+
+	source, err := generate(&s, specPath, packageName)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outPath, source, 0o644)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The subset of OpenAPI 3 understood by the generator. Anything else in the
+// document is ignored, apart from the constructs rejected by the generator
+// itself.
+type spec struct {
+	Paths      orderedMap[pathItem] `json:"paths"`
+	Components struct {
+		Schemas orderedMap[*schema] `json:"schemas"`
+	} `json:"components"`
+}
+
+type pathItem struct {
+	Get    *operation `json:"get"`
+	Post   *operation `json:"post"`
+	Put    *operation `json:"put"`
+	Delete *operation `json:"delete"`
+	Patch  *operation `json:"patch"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []parameter          `json:"parameters"`
+	RequestBody *requestBody         `json:"requestBody"`
+	Responses   orderedMap[response] `json:"responses"`
+	// Describes the operation in error messages, e.g. "getting network"
+	Activity string `json:"x-activity"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string              `json:"$ref"`
+	Type                 string              `json:"type"`
+	Format               string              `json:"format"`
+	Description          string              `json:"description"`
+	Properties           orderedMap[*schema] `json:"properties"`
+	Required             []string            `json:"required"`
+	Items                *schema             `json:"items"`
+	AdditionalProperties json.RawMessage     `json:"additionalProperties"`
+	Enum                 []string            `json:"enum"`
+
+	// Extensions shared with oapi-codegen, so that the document can be fed
+	// to it unchanged
+	GoName              string   `json:"x-go-name"`
+	GoType              string   `json:"x-go-type"`
+	SkipOptionalPointer bool     `json:"x-go-type-skip-optional-pointer"`
+	EnumVarNames        []string `json:"x-enum-varnames"`
+}
+
+// additional returns the schema of the values of a map, nil when any value
+// goes, or false when the object is not a map
+func (s *schema) additional() (*schema, bool, error) {
+	raw := bytes.TrimSpace(s.AdditionalProperties)
+	switch {
+	case len(raw) == 0, string(raw) == "false":
+		return nil, false, nil
+	case string(raw) == "true", string(raw) == "{}":
+		return nil, true, nil
+	}
+
+	var values schema
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, false, fmt.Errorf("invalid additionalProperties: %w", err)
+	}
+	return &values, true, nil
+}
+
+func (s *schema) isRequired(property string) bool {
+	for _, name := range s.Required {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
+
+// refName returns the name of the component a reference points to
+func refName(ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return "", fmt.Errorf("unsupported reference %q, only #/components/schemas/ is", ref)
+	}
+	return name, nil
+}
+
+// orderedMap is a JSON object whose keys keep the order of the document,
+// which decides the order of struct fields and generated declarations
+type orderedMap[T any] struct {
+	keys   []string
+	values map[string]T
+}
+
+func (m *orderedMap[T]) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected an object, got %v", token)
+	}
+
+	m.keys = nil
+	m.values = map[string]T{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+
+		var value T
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if _, found := m.values[key]; !found {
+			m.keys = append(m.keys, key)
+		}
+		m.values[key] = value
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+func (m *orderedMap[T]) each(f func(key string, value T) error) error {
+	for _, key := range m.keys {
+		if err := f(key, m.values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}